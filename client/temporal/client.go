@@ -31,6 +31,12 @@ type (
 		TLSKeyFilePath  string
 	}
 
+	LocalAuth struct {
+		// The grpc address of a local or self-hosted Temporal server
+		// defaults to 'localhost:7233', the address of the Temporal CLI dev server
+		HostPort string
+	}
+
 	GetTemporalCloudNamespaceClientInput struct {
 		// The temporal cloud namespace to connect to (required) for e.g. "prod.a2dd6"
 		Namespace string `required:"true"`
@@ -113,6 +119,16 @@ func (a *MtlsAuth) apply(options *client.Options) error {
 	return nil
 }
 
+func (a *LocalAuth) apply(options *client.Options) error {
+	options.HostPort = a.HostPort
+	if options.HostPort == "" {
+		options.HostPort = client.DefaultHostPort
+	}
+	return nil
+}
+
+// GetTemporalCloudNamespaceClient dials a client for the namespace using the given auth. A nil Auth is treated as an
+// empty LocalAuth, so the client connects without authentication to a local dev server at localhost:7233
 func GetTemporalCloudNamespaceClient(ctx context.Context, input *GetTemporalCloudNamespaceClientInput) (client.Client, error) {
 	err := validator.ValidateStruct(input)
 	if err != nil {
@@ -123,7 +139,11 @@ func GetTemporalCloudNamespaceClient(ctx context.Context, input *GetTemporalClou
 		Namespace: input.Namespace,
 		Logger:    input.Logger,
 	}
-	err = input.Auth.apply(&opts)
+	auth := input.Auth
+	if auth == nil {
+		auth = &LocalAuth{}
+	}
+	err = auth.apply(&opts)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// activitiesCommand prints activity level statistics, or every activity run, for the selected executions
+func activitiesCommand(args []string) error {
+	fs := newFlagSet("exporttool activities", "exporttool activities [--activity-type type] [--runs] [filter flags] /path/to/export/file [...]")
+	var activityTypes stringList
+	fs.Var(&activityTypes, "activity-type", "only include activities of this type. May be repeated or comma separated")
+	listRuns := fs.Bool("runs", false, "list every activity run instead of the per activity type summary")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	var runs []*export.ActivityRun
+	err := input.readWorkflows(fs.Args(), func(_ string, workflow *exportpb.WorkflowExecution) error {
+		for _, run := range export.ActivityRuns(workflow) {
+			if len(activityTypes) == 0 || slices.Contains(activityTypes, run.ActivityType) {
+				runs = append(runs, run)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if *listRuns {
+		return writeActivityRuns(os.Stdout, runs)
+	}
+	return writeActivitySummaries(os.Stdout, export.SummarizeActivities(runs))
+}
+
+func writeActivitySummaries(w io.Writer, summaries []*export.ActivityTypeSummary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "ACTIVITY TYPE\tRUNS")
+	for _, status := range export.ActivityStatuses {
+		fmt.Fprintf(tw, "\t%s", status)
+	}
+	fmt.Fprintln(tw, "\tRETRIED\tMAX ATTEMPT\tQUEUE P50\tP95\tEXECUTION P50\tP95\tP99\tMAX")
+	for _, summary := range summaries {
+		fmt.Fprintf(tw, "%s\t%d", summary.ActivityType, summary.Runs)
+		for _, status := range export.ActivityStatuses {
+			fmt.Fprintf(tw, "\t%d", summary.Statuses[status])
+		}
+		q, e := summary.QueueLatency, summary.ExecutionTime
+		fmt.Fprintf(tw, "\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n", percent(summary.Retried, summary.Runs), summary.Attempts.Max,
+			formatDuration(q.P50), formatDuration(q.P95), formatDuration(e.P50), formatDuration(e.P95), formatDuration(e.P99), formatDuration(e.Max))
+	}
+	return tw.Flush()
+}
+
+func writeActivityRuns(w io.Writer, runs []*export.ActivityRun) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORKFLOW ID\tRUN ID\tEVENT ID\tACTIVITY TYPE\tSTATUS\tATTEMPT\tQUEUE LATENCY\tEXECUTION TIME\tFAILURE")
+	for _, run := range runs {
+		message, _, _ := strings.Cut(run.Failure.GetMessage(), "\n")
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%d\t%s\t%s\t%s\n", run.WorkflowID, run.RunID, run.ScheduledEventID, run.ActivityType,
+			run.Status, run.Attempt, formatDuration(run.QueueLatency), formatDuration(run.ExecutionTime), message)
+	}
+	return tw.Flush()
+}
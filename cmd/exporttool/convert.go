@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/temporalio/cloud-samples-go/export/parquet"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+const (
+	convertParquet = "parquet"
+
+	// Names of the files written by convert --format parquet
+	executionsParquetFile = "executions.parquet"
+	eventsParquetFile     = "events.parquet"
+)
+
+// convertCommand converts the selected executions into files for data warehouse tooling
+func convertCommand(args []string) error {
+	fs := newFlagSet("exporttool convert", "exporttool convert --format parquet [--out-dir dir] [filter flags] /path/to/export/file [...]")
+	format := fs.String("format", "", "output format: "+convertParquet)
+	outDir := fs.String("out-dir", ".", "directory to write "+executionsParquetFile+" and "+eventsParquetFile+" to")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	if *format != convertParquet {
+		return fmt.Errorf("unknown format %q, must be %s", *format, convertParquet)
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+	executionsFile, err := os.Create(filepath.Join(*outDir, executionsParquetFile))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer executionsFile.Close()
+	eventsFile, err := os.Create(filepath.Join(*outDir, eventsParquetFile))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer eventsFile.Close()
+
+	writer := parquet.NewWriter(executionsFile, eventsFile)
+	var converted int
+	err = input.readWorkflowBatches(fs.Args(), func(path string, workflows []*exportpb.WorkflowExecution) error {
+		if err := writer.Write(workflows); err != nil {
+			return fmt.Errorf("error converting %s: %w", path, err)
+		}
+		converted += len(workflows)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := executionsFile.Close(); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+	if err := eventsFile.Close(); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+
+	fmt.Printf("Converted %d workflows to %s and %s\r\n", converted, executionsFile.Name(), eventsFile.Name())
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// diffCommand compares the histories of two executions, each given as path:runID
+func diffCommand(args []string) error {
+	fs := newFlagSet("exporttool diff", "exporttool diff [--ignore field] [--include-volatile] /path/to/export/file:runID /path/to/export/file:runID")
+	var ignored stringList
+	fs.Var(&ignored, "ignore", "additional field to ignore, in snake case as in the proto definitions. May be repeated or comma separated")
+	includeVolatile := fs.Bool("include-volatile", false, "also compare timestamps and the fields ignored by default: "+strings.Join(export.DefaultIgnoredFields, ", "))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	var workflows [2]*exportpb.WorkflowExecution
+	for i, arg := range fs.Args() {
+		path, runID, err := parseExecution(arg)
+		if err != nil {
+			return err
+		}
+		workflow, err := findWorkflow([]string{path}, runID)
+		if err != nil {
+			return err
+		}
+		workflows[i] = workflow
+	}
+
+	options := export.DiffOptions{IgnoredFields: slices.Concat(export.DefaultIgnoredFields, ignored)}
+	if *includeVolatile {
+		// A non-nil slice, even when empty, keeps DiffHistories from falling back to the default ignored fields
+		options = export.DiffOptions{IgnoredFields: append([]string{}, ignored...), CompareTimestamps: true}
+	}
+	diffs := export.DiffHistories(workflows[0].GetHistory(), workflows[1].GetHistory(), options)
+
+	fmt.Printf("--- %s\r\n+++ %s\r\n", fs.Arg(0), fs.Arg(1))
+	for _, diff := range diffs {
+		switch diff.Kind {
+		case export.EventRemoved:
+			fmt.Printf("- %d %s\r\n", diff.EventID, diff.Left.GetEventType())
+		case export.EventAdded:
+			fmt.Printf("+ %d %s\r\n", diff.EventID, diff.Right.GetEventType())
+		case export.EventChanged:
+			fmt.Printf("~ %d %s\r\n", diff.EventID, diff.Left.GetEventType())
+			for _, field := range diff.Fields {
+				fmt.Printf("    %s: %s -> %s\r\n", field.Path, field.Left, field.Right)
+			}
+		}
+	}
+
+	if len(diffs) > 0 {
+		return fmt.Errorf("histories differ in %d events", len(diffs))
+	}
+	fmt.Printf("Histories are identical\r\n")
+	return nil
+}
+
+// parseExecution splits an argument of the form path:runID
+func parseExecution(arg string) (path, runID string, err error) {
+	i := strings.LastIndex(arg, ":")
+	if i <= 0 || i == len(arg)-1 {
+		return "", "", fmt.Errorf("invalid execution %q, expected /path/to/export/file:runID", arg)
+	}
+	return arg[:i], arg[i+1:], nil
+}
+
+// findWorkflow returns the execution with the given run ID from the first of the export files that contains it. Only if
+// no execution has the run ID, an execution reset from that run is returned, since a reset run records the run ID of
+// its base run as the run ID it was started with
+func findWorkflow(paths []string, runID string) (*exportpb.WorkflowExecution, error) {
+	var reset *exportpb.WorkflowExecution
+	for _, path := range paths {
+		workflow, resetFrom, err := findFileWorkflow(path, runID)
+		if workflow != nil || err != nil {
+			return workflow, err
+		}
+		if reset == nil {
+			reset = resetFrom
+		}
+	}
+	if reset != nil {
+		return reset, nil
+	}
+	return nil, fmt.Errorf("run ID %s not found in %s", runID, strings.Join(paths, ", "))
+}
+
+// findFileWorkflow returns the execution with the given run ID from an export file, or else the first execution that
+// was started with the run ID and reset to another one
+func findFileWorkflow(path, runID string) (workflow, resetFrom *exportpb.WorkflowExecution, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading file: %w", err)
+	}
+	defer file.Close()
+
+	for workflow, err := range export.Executions(file) {
+		if err != nil {
+			return nil, nil, fmt.Errorf("error extracting workflow histories from %s: %w", path, err)
+		}
+		info, err := export.GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			continue
+		}
+		if info.RunID == runID {
+			return workflow, nil, nil
+		}
+		if resetFrom == nil && info.OriginalRunID == runID {
+			resetFrom = workflow
+		}
+	}
+	return nil, resetFrom, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	exportpb "go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	"github.com/temporalio/cloud-samples-go/internal/exporttest"
+)
+
+// resetRun returns a copy of base that records a reset to newRunID, which is all the run lookup looks at
+func resetRun(base *exportpb.WorkflowExecution, newRunID string) *exportpb.WorkflowExecution {
+	reset := proto.Clone(base).(*exportpb.WorkflowExecution)
+	events := reset.GetHistory().GetEvents()
+	failed := &historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED,
+		Attributes: &historypb.HistoryEvent_WorkflowTaskFailedEventAttributes{
+			WorkflowTaskFailedEventAttributes: &historypb.WorkflowTaskFailedEventAttributes{
+				Cause:     enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW,
+				BaseRunId: events[0].GetWorkflowExecutionStartedEventAttributes().GetOriginalExecutionRunId(),
+				NewRunId:  newRunID,
+			},
+		},
+	}
+	reset.History.Events = append([]*historypb.HistoryEvent{events[0], failed}, events[1:]...)
+	return reset
+}
+
+func TestFindWorkflowResetRuns(t *testing.T) {
+	base := exporttest.Workflows(t, 1)[0]
+	reset := resetRun(base, "reset-run")
+	info, err := export.GetExportedWorkflowExecutionInfo(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseRunID := info.RunID
+
+	dir := t.TempDir()
+	resetOnly := filepath.Join(dir, "reset.bin")
+	exporttest.WriteFile(t, resetOnly, []*exportpb.WorkflowExecution{reset})
+	both := filepath.Join(dir, "both.bin")
+	exporttest.WriteFile(t, both, []*exportpb.WorkflowExecution{reset, base})
+
+	tests := []struct {
+		name  string
+		paths []string
+		runID string
+		want  *exportpb.WorkflowExecution
+	}{
+		{name: "base run after its reset run", paths: []string{both}, runID: baseRunID, want: base},
+		{name: "base run in a later file", paths: []string{resetOnly, both}, runID: baseRunID, want: base},
+		{name: "reset run", paths: []string{both}, runID: "reset-run", want: reset},
+		{name: "base run missing", paths: []string{resetOnly}, runID: baseRunID, want: reset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findWorkflow(tt.paths, tt.runID)
+			if err != nil {
+				t.Fatalf("findWorkflow: %v", err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("findWorkflow returned the wrong run")
+			}
+		})
+	}
+
+	if _, err := findWorkflow([]string{both}, "missing"); err == nil {
+		t.Error("findWorkflow found a run ID that is not in the export")
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/temporalio/cloud-samples-go/export"
+)
+
+// extractCommand writes the history of a single execution as JSON for the Temporal CLI and SDK replayer
+func extractCommand(args []string) error {
+	fs := newFlagSet("exporttool extract", "exporttool extract --run-id runID [--out history.json] /path/to/export/file [...]")
+	runID := fs.String("run-id", "", "run ID of the execution to extract")
+	out := fs.String("out", "", "file to write the history to, defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *runID == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	paths, err := expandPaths(fs.Args())
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+	workflow, err := findWorkflow(paths, *runID)
+	if err != nil {
+		return err
+	}
+
+	data, err := export.MarshalHistoryJSON(workflow)
+	if err != nil {
+		return fmt.Errorf("error marshaling workflow history: %w", err)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+	fmt.Printf("Wrote history of run %s to %s\r\n", *runID, *out)
+	return nil
+}
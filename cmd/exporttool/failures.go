@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// failuresCommand groups the failed, timed out and terminated executions in the given export files by failure
+// signature and prints the most common signatures
+func failuresCommand(args []string) error {
+	fs := newFlagSet("exporttool failures", "exporttool failures [--top N] [--examples N] [filter flags] /path/to/export/file [...]")
+	top := fs.Int("top", 10, "number of failure signatures to show, 0 shows every signature")
+	examples := fs.Int("examples", 3, "number of example executions to show per failure signature")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 || *examples < 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	grouper := export.NewFailureGrouper(*examples)
+	err := input.readWorkflows(fs.Args(), func(_ string, workflow *exportpb.WorkflowExecution) error {
+		grouper.Add(workflow)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writeFailureGroups(os.Stdout, grouper, *top)
+	return nil
+}
+
+func writeFailureGroups(w io.Writer, grouper *export.FailureGrouper, top int) {
+	groups := grouper.Groups()
+	var failed int
+	for _, group := range groups {
+		failed += group.Count
+	}
+	fmt.Fprintf(w, "Failed executions: %d of %d (%s), %d signatures\n", failed, grouper.Executions(), percent(failed, grouper.Executions()), len(groups))
+	if top > 0 && len(groups) > top {
+		groups = groups[:top]
+	}
+
+	for i, group := range groups {
+		fmt.Fprintf(w, "\n#%d  %d executions (%s)", i+1, group.Count, percent(group.Count, failed))
+		for _, status := range reportedStatuses {
+			if count := group.Statuses[status]; count > 0 {
+				fmt.Fprintf(w, "  %s: %d", status, count)
+			}
+		}
+		fmt.Fprintf(w, "\n    %s\n", group.Signature)
+
+		root := group.Chain[len(group.Chain)-1]
+		if root.Message != "" {
+			fmt.Fprintf(w, "    Message: %s\n", root.Message)
+		}
+		for j, frame := range root.Frames {
+			if j == 0 {
+				fmt.Fprintf(w, "    Stack:   %s\n", frame)
+			} else {
+				fmt.Fprintf(w, "             %s\n", frame)
+			}
+		}
+
+		workflowTypes := make([]string, 0, len(group.WorkflowTypes))
+		for workflowType := range group.WorkflowTypes {
+			workflowTypes = append(workflowTypes, workflowType)
+		}
+		slices.Sort(workflowTypes)
+		for j, workflowType := range workflowTypes {
+			workflowTypes[j] = fmt.Sprintf("%s (%d)", workflowType, group.WorkflowTypes[workflowType])
+		}
+		fmt.Fprintf(w, "    Workflow types: %s\n", strings.Join(workflowTypes, ", "))
+
+		fmt.Fprintln(w, "    Examples:")
+		for _, example := range group.Examples {
+			fmt.Fprintf(w, "      WorkflowID: %s, RunID: %s, EventID: %d, Closed: %s\n",
+				example.WorkflowID, example.RunID, example.EventID, formatTime(example.CloseTime))
+		}
+	}
+}
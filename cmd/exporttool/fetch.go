@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/temporalio/cloud-samples-go/client/temporal"
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+	"go.temporal.io/sdk/log"
+)
+
+// fetchCommand writes the histories of closed executions in a namespace to an export file, in the format used by the
+// Temporal Cloud export feature
+func fetchCommand(args []string) error {
+	fs := newFlagSet("exporttool fetch", "exporttool fetch [--namespace default] [--address localhost:7233] [--query query] [--limit N] --out fetched.export")
+	namespace := fs.String("namespace", "default", "namespace to fetch workflows from")
+	address := fs.String("address", "", "grpc address of the Temporal server, defaults to localhost:7233 for a local dev server and to <namespace>.tmprl.cloud:7233 with --tls-cert, cannot be combined with --api-key")
+	apiKey := fs.String("api-key", "", "Temporal Cloud API key, used to look up the namespace's endpoint and to authenticate")
+	tlsCert := fs.String("tls-cert", "", "path of the TLS certificate for mTLS authentication with Temporal Cloud")
+	tlsKey := fs.String("tls-key", "", "path of the TLS key for mTLS authentication with Temporal Cloud")
+	query := fs.String("query", "", "visibility query selecting the workflows to fetch, e.g. 'WorkflowType = \"order\"', only closed workflows are fetched")
+	limit := fs.Int("limit", 0, "maximum number of workflows to fetch, 0 fetches every matching workflow")
+	parallelism := fs.Int("parallelism", 4, "number of workflow histories fetched concurrently")
+	out := fs.String("out", "", "path of the export file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *out == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	if *apiKey != "" && (*address != "" || *tlsCert != "" || *tlsKey != "") {
+		return fmt.Errorf("--api-key looks up the namespace's endpoint through the Cloud API and cannot be combined with --address, --tls-cert or --tls-key")
+	}
+
+	var auth temporal.AuthType
+	switch {
+	case *tlsCert != "" || *tlsKey != "":
+		auth = &temporal.MtlsAuth{GRPCEndpoint: *address, TLSCertFilePath: *tlsCert, TLSKeyFilePath: *tlsKey}
+	case *apiKey != "":
+		auth = &temporal.ApiKeyAuth{APIKey: *apiKey}
+	default:
+		auth = &temporal.LocalAuth{HostPort: *address}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, err := temporal.GetTemporalCloudNamespaceClient(ctx, &temporal.GetTemporalCloudNamespaceClientInput{
+		Namespace: *namespace,
+		Auth:      auth,
+		Logger:    log.NewStructuredLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	})
+	if err != nil {
+		return fmt.Errorf("error connecting to namespace %s: %w", *namespace, err)
+	}
+	defer c.Close()
+
+	file, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer file.Close()
+
+	writer := export.NewWriter(file)
+	options := export.FetchOptions{Query: *query, Limit: *limit, Parallelism: *parallelism}
+	err = export.FetchWorkflows(ctx, c, options, func(workflow *exportpb.WorkflowExecution) error {
+		return writer.Write(workflow)
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+
+	fmt.Printf("Fetched %d workflows from %s to %s\r\n", writer.Count(), *namespace, *out)
+	return nil
+}
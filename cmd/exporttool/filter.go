@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+type (
+	// stringList is a flag.Value that accumulates repeated and comma separated flag values
+	stringList []string
+
+	// repeatedString is a flag.Value that accumulates repeated flag values without splitting them on commas
+	repeatedString []string
+
+	// filterFlags holds the command line flags used to select a subset of the exported workflow executions
+	filterFlags struct {
+		types         stringList
+		statuses      stringList
+		idGlob        string
+		idRegex       string
+		startedAfter  string
+		startedBefore string
+		closedAfter   string
+		closedBefore  string
+		searchAttrs   repeatedString
+	}
+)
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*s = append(*s, v)
+		}
+	}
+	return nil
+}
+
+func (s *repeatedString) String() string {
+	return strings.Join(*s, " ")
+}
+
+func (s *repeatedString) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.types, "type", "only include workflows of this type, may be repeated or comma separated")
+	fs.Var(&f.statuses, "status", "only include workflows with this close status (e.g. completed, failed, timed-out, canceled, terminated, continued-as-new, running), may be repeated or comma separated")
+	fs.StringVar(&f.idGlob, "id", "", "only include workflows whose ID matches this glob pattern, '*' matches any characters")
+	fs.StringVar(&f.idRegex, "id-regex", "", "only include workflows whose ID matches this regular expression")
+	fs.StringVar(&f.startedAfter, "started-after", "", "only include workflows started at or after this RFC3339 time")
+	fs.StringVar(&f.startedBefore, "started-before", "", "only include workflows started before this RFC3339 time")
+	fs.StringVar(&f.closedAfter, "closed-after", "", "only include workflows closed at or after this RFC3339 time")
+	fs.StringVar(&f.closedBefore, "closed-before", "", "only include workflows closed before this RFC3339 time")
+	fs.Var(&f.searchAttrs, "search-attribute", "only include workflows whose final search attribute matches Name=value (e.g. CustomKeywordField=foo), or that have Name set, may be repeated")
+}
+
+// build converts the parsed flags into a single export.Filter, which selects every workflow if no flags were set
+func (f *filterFlags) build() (export.Filter, error) {
+	var filters []export.Filter
+
+	if len(f.types) > 0 {
+		filters = append(filters, export.ByWorkflowType(f.types...))
+	}
+
+	if len(f.statuses) > 0 {
+		statuses := make([]enumspb.WorkflowExecutionStatus, 0, len(f.statuses))
+		for _, s := range f.statuses {
+			status, err := export.ParseWorkflowStatus(s)
+			if err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, status)
+		}
+		filters = append(filters, export.ByStatus(statuses...))
+	}
+
+	if f.idGlob != "" {
+		pattern, err := export.GlobToRegexp(f.idGlob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --id pattern: %w", err)
+		}
+		filters = append(filters, export.ByWorkflowID(pattern))
+	}
+
+	if f.idRegex != "" {
+		pattern, err := regexp.Compile(f.idRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --id-regex pattern: %w", err)
+		}
+		filters = append(filters, export.ByWorkflowID(pattern))
+	}
+
+	if f.startedAfter != "" || f.startedBefore != "" {
+		from, to, err := parseWindow("started", f.startedAfter, f.startedBefore)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, export.StartedBetween(from, to))
+	}
+
+	if f.closedAfter != "" || f.closedBefore != "" {
+		from, to, err := parseWindow("closed", f.closedAfter, f.closedBefore)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, export.ClosedBetween(from, to))
+	}
+
+	for _, searchAttr := range f.searchAttrs {
+		name, value, _ := strings.Cut(searchAttr, "=")
+		if name = strings.TrimSpace(name); name == "" {
+			return nil, fmt.Errorf("invalid --search-attribute %q, expected Name=value or Name", searchAttr)
+		}
+		filters = append(filters, export.BySearchAttribute(name, value))
+	}
+
+	return export.MatchAll(filters...), nil
+}
+
+func parseWindow(name, after, before string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if after != "" {
+		from, err = time.Parse(time.RFC3339, after)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid --%s-after time: %w", name, err)
+		}
+	}
+	if before != "" {
+		to, err = time.Parse(time.RFC3339, before)
+		if err != nil {
+			return from, to, fmt.Errorf("invalid --%s-before time: %w", name, err)
+		}
+	}
+	return from, to, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// generateCommand writes an export file of synthetic workflow executions, either generated from a template or
+// converted from workflow history JSON files
+func generateCommand(args []string) error {
+	fs := newFlagSet("exporttool generate", "exporttool generate [--template template.json] [--count 100] [--seed 1] --out generated.export")
+	templatePath := fs.String("template", "", "path of the JSON generator template, defaults to a template modelled on the namespace workflows")
+	count := fs.Int("count", 100, "number of workflow executions to generate")
+	seed := fs.Uint64("seed", 1, "seed of the random generator, the same template and seed produce the same executions if the template sets a startTime")
+	fromHistory := fs.Bool("from-history", false, "convert the workflow history JSON files given as arguments, for example from 'temporal workflow show --output json', instead of generating executions")
+	out := fs.String("out", "", "path of the export file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *out == "" || *fromHistory != (fs.NArg() > 0) {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	var workflows exportpb.WorkflowExecutions
+	if *fromHistory {
+		for _, path := range fs.Args() {
+			history, err := readHistoryJSON(path)
+			if err != nil {
+				return err
+			}
+			workflows.Items = append(workflows.Items, &exportpb.WorkflowExecution{History: history})
+		}
+	} else {
+		template := export.DefaultGenerateTemplate()
+		if *templatePath != "" {
+			var err error
+			if template, err = export.LoadGenerateTemplate(*templatePath); err != nil {
+				return err
+			}
+		}
+		generator, err := export.NewGenerator(template, *seed)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < *count; i++ {
+			workflow, err := generator.Next()
+			if err != nil {
+				return err
+			}
+			workflows.Items = append(workflows.Items, workflow)
+		}
+	}
+
+	data, err := export.SerializeExportedWorkflows(&workflows)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+
+	fmt.Printf("Wrote %d workflows to %s\r\n", len(workflows.Items), *out)
+	return nil
+}
+
+// readHistoryJSON reads a workflow history in the JSON format written by the Temporal CLI and UI
+func readHistoryJSON(path string) (*historypb.History, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+	defer file.Close()
+
+	history, err := client.HistoryFromJSON(file, client.HistoryJSONOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing history %s: %w", path, err)
+	}
+	if _, err := export.GetWorkflowStartedEventAttributes(&exportpb.WorkflowExecution{History: history}); err != nil {
+		return nil, fmt.Errorf("invalid history %s: %w", path, err)
+	}
+	return history, nil
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// workflowBatchSize is the maximum number of workflow executions passed to a readWorkflowBatches callback at once
+const workflowBatchSize = 1000
+
+// fileBufferSize is the number of decoded workflow executions buffered for each export file that is decoded ahead of
+// the file being consumed
+const fileBufferSize = 64
+
+type (
+	// inputFlags holds the command line flags shared by every command that reads export files
+	inputFlags struct {
+		parallelism int
+		filters     filterFlags
+	}
+
+	// fileResult streams the selected workflow executions decoded from a single export file. err is set before
+	// workflows is closed, and must only be read once workflows has been drained
+	fileResult struct {
+		path      string
+		workflows chan *exportpb.WorkflowExecution
+		err       error
+	}
+)
+
+func (f *inputFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&f.parallelism, "parallelism", runtime.NumCPU(), "maximum number of export files decoded concurrently")
+	f.filters.register(fs)
+}
+
+// readWorkflows expands args into export files and calls fn for every workflow execution selected by the filter flags,
+// in sorted file order and in the order the executions appear within each file. Executions are streamed out of each
+// file as they are decoded, so no file is ever held in memory as a whole
+func (f *inputFlags) readWorkflows(args []string, fn func(path string, workflow *exportpb.WorkflowExecution) error) error {
+	filter, err := f.filters.build()
+	if err != nil {
+		return err
+	}
+
+	paths, err := expandPaths(args)
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+
+	return readFiles(paths, f.parallelism, filter, func(result *fileResult) error {
+		for workflow := range result.workflows {
+			if err := fn(result.path, workflow); err != nil {
+				return err
+			}
+		}
+		if result.err != nil {
+			return fmt.Errorf("error extracting workflow histories from %s: %w", result.path, result.err)
+		}
+		return nil
+	})
+}
+
+// readWorkflowBatches is like readWorkflows, but calls fn with batches of at most workflowBatchSize selected executions
+// that all come from the same file
+func (f *inputFlags) readWorkflowBatches(args []string, fn func(path string, workflows []*exportpb.WorkflowExecution) error) error {
+	var batch []*exportpb.WorkflowExecution
+	var batchPath string
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := fn(batchPath, batch)
+		batch = nil
+		return err
+	}
+
+	err := f.readWorkflows(args, func(path string, workflow *exportpb.WorkflowExecution) error {
+		if path != batchPath || len(batch) == workflowBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			batchPath = path
+		}
+		batch = append(batch, workflow)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+// expandPaths resolves command line arguments into a sorted list of export files. Each argument may be a file, a
+// directory, which is searched recursively, or a glob pattern. Hidden files are skipped when walking directories
+func expandPaths(args []string) ([]string, error) {
+	seen := map[string]bool{}
+	var paths []string
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, arg := range args {
+		matches := []string{arg}
+		if _, err := os.Stat(arg); err != nil {
+			if !strings.ContainsAny(arg, "*?[") {
+				return nil, err
+			}
+			matches, err = filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob pattern %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %q", arg)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				add(match)
+				continue
+			}
+			err = filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if path != match && strings.HasPrefix(d.Name(), ".") {
+					if d.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if d.Type().IsRegular() {
+					add(path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read directory %s: %w", match, err)
+			}
+		}
+	}
+
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// readFiles decodes the given files concurrently with at most parallelism files in flight, and calls fn with the
+// result for each file in the order the paths were given regardless of the order in which decoding completes. A file
+// only counts towards the limit until fn has returned for it, and files ahead of the one being consumed only decode
+// until their buffer is full, which bounds the number of decoded executions held in memory
+func readFiles(paths []string, parallelism int, filter export.Filter, fn func(result *fileResult) error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	sem := make(chan struct{}, parallelism)
+	done := make(chan struct{})
+	defer close(done)
+
+	results := make([]*fileResult, len(paths))
+	for i, path := range paths {
+		results[i] = &fileResult{path: path, workflows: make(chan *exportpb.WorkflowExecution, fileBufferSize)}
+	}
+
+	go func() {
+		for _, result := range results {
+			select {
+			case sem <- struct{}{}:
+			case <-done:
+				return
+			}
+			go func() {
+				defer close(result.workflows)
+				result.err = readFile(result.path, filter, func(workflow *exportpb.WorkflowExecution) error {
+					select {
+					case result.workflows <- workflow:
+						return nil
+					case <-done:
+						return errStopped
+					}
+				})
+			}()
+		}
+	}()
+
+	for _, result := range results {
+		err := fn(result)
+		<-sem
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errStopped stops decoding a file once its results are no longer consumed
+var errStopped = errors.New("stopped reading")
+
+// readFile streams the workflow executions out of a single export file and calls fn with each one selected by filter
+func readFile(path string, filter export.Filter, fn func(workflow *exportpb.WorkflowExecution) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for workflow, err := range export.Executions(file) {
+		if err != nil {
+			return err
+		}
+		if filter(workflow) {
+			if err := fn(workflow); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/temporalio/cloud-samples-go/export/sqlite"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// loadCommand loads the selected executions into a SQLite database for querying with SQL
+func loadCommand(args []string) error {
+	fs := newFlagSet("exporttool load", "exporttool load --sqlite out.db [filter flags] /path/to/export/file [...]")
+	dbPath := fs.String("sqlite", "", "path of the SQLite database to load the executions into, created if it does not exist")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *dbPath == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	loader, err := sqlite.Open(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer loader.Close()
+
+	var loaded int
+	err = input.readWorkflowBatches(fs.Args(), func(path string, workflows []*exportpb.WorkflowExecution) error {
+		if err := loader.Load(context.Background(), workflows); err != nil {
+			return fmt.Errorf("error loading %s: %w", path, err)
+		}
+		loaded += len(workflows)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Loaded %d workflows into %s\r\n", loaded, *dbPath)
+	return nil
+}
@@ -1,45 +1,54 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
-
-	"github.com/temporalio/cloud-samples-go/export"
 )
 
-func main() {
-	if len(os.Args) != 2 {
-		fmt.Println("Example usage: exporttool /path/to/export/file")
-		os.Exit(1)
-	}
-
-	filename := os.Args[1]
+// commands maps subcommand names to their implementations. Running exporttool without a subcommand prints the
+// executions in the given export files
+var commands = map[string]func(args []string) error{
+	"stats":      statsCommand,
+	"replay":     replayCommand,
+	"load":       loadCommand,
+	"convert":    convertCommand,
+	"verify":     verifyCommand,
+	"diff":       diffCommand,
+	"extract":    extractCommand,
+	"redact":     redactCommand,
+	"activities": activitiesCommand,
+	"tree":       treeCommand,
+	"watch":      watchCommand,
+	"generate":   generateCommand,
+	"fetch":      fetchCommand,
+	"failures":   failuresCommand,
+}
 
-	bytes, err := os.ReadFile(filename)
-	if err != nil {
-		fmt.Printf("error reading file: %v\r\n", err)
-		os.Exit(1)
+func main() {
+	run := printCommand
+	args := os.Args[1:]
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			run = cmd
+			args = args[1:]
+		}
 	}
 
-	workflows, err := export.DeserializeExportedWorkflows(bytes)
-	if err != nil {
-		fmt.Printf("error extracting workflow histories: %v\r\n", err)
+	if err := run(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Printf("%v\r\n", err)
+		}
 		os.Exit(1)
 	}
+}
 
-	fmt.Printf("Successfully deserialized %d workflows \r\n", len(workflows.Items))
-
-	for _, workflow := range workflows.Items {
-		info, err := export.GetExportedWorkflowInformation(workflow)
-		if err != nil {
-			fmt.Printf("error extracting workflow information: %v\r\n", err)
-			os.Exit(1)
-		}
-
-		fmt.Println(info)
-
-		fmt.Println(export.FormatWorkflow(workflow))
-		fmt.Println("----------------------------------------------------------")
-		fmt.Println()
+// newFlagSet returns a flag set for a command that reports usage with the given example invocation
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Example usage: %s\n", usage)
+		fs.PrintDefaults()
 	}
+	return fs
 }
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+const (
+	outputText  = "text"
+	outputJSONL = "jsonl"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+var outputFormats = []string{outputText, outputJSONL, outputJSON, outputCSV}
+
+const (
+	viewFull     = "full"
+	viewTimeline = "timeline"
+)
+
+type (
+	// outputWriter renders exported workflow executions in one of the supported output formats
+	outputWriter interface {
+		Write(workflow *exportpb.WorkflowExecution) error
+		// Close flushes any buffered output and writes trailing content, it does not close the underlying writer
+		Close() error
+	}
+
+	textWriter struct {
+		w        io.Writer
+		renderer *export.PayloadRenderer
+		timeline bool
+		count    int
+	}
+
+	jsonlWriter struct {
+		w        io.Writer
+		renderer *export.PayloadRenderer
+	}
+
+	jsonWriter struct {
+		w        io.Writer
+		renderer *export.PayloadRenderer
+		count    int
+	}
+
+	csvWriter struct {
+		w *csv.Writer
+	}
+)
+
+// newOutputWriter returns a writer for the given format. When renderer is not nil, payloads in the text and JSON
+// formats are decoded and rendered as readable values
+func newOutputWriter(format string, w io.Writer, renderer *export.PayloadRenderer) (outputWriter, error) {
+	switch format {
+	case outputText:
+		return &textWriter{w: w, renderer: renderer}, nil
+	case outputJSONL:
+		return &jsonlWriter{w: w, renderer: renderer}, nil
+	case outputJSON:
+		return &jsonWriter{w: w, renderer: renderer}, nil
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		err := cw.Write([]string{"workflow_id", "run_id", "workflow_type", "start_time", "close_time", "status", "event_count"})
+		if err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, must be one of %v", format, outputFormats)
+	}
+}
+
+func (t *textWriter) Write(workflow *exportpb.WorkflowExecution) error {
+	info, err := export.GetExportedWorkflowInformation(workflow)
+	if err != nil {
+		return fmt.Errorf("error extracting workflow information: %w", err)
+	}
+	t.count++
+
+	formatted := export.FormatWorkflow(workflow)
+	if t.timeline {
+		formatted = export.FormatTimeline(workflow)
+	} else if t.renderer != nil {
+		formatted, err = t.renderer.FormatWorkflow(context.Background(), workflow)
+		if err != nil {
+			return fmt.Errorf("error rendering workflow payloads: %w", err)
+		}
+	}
+
+	fmt.Fprintln(t.w, info)
+	if searchAttributes := export.FinalSearchAttributes(workflow); len(searchAttributes.GetIndexedFields()) > 0 {
+		fmt.Fprintf(t.w, "SearchAttributes: %s\n", export.FormatSearchAttributes(searchAttributes))
+	}
+	if memo := export.FinalMemo(workflow); len(memo.GetFields()) > 0 {
+		fmt.Fprintf(t.w, "Memo: %s\n", export.FormatMemo(memo))
+	}
+	fmt.Fprintln(t.w, formatted)
+	fmt.Fprintln(t.w, "----------------------------------------------------------")
+	_, err = fmt.Fprintln(t.w)
+	return err
+}
+
+func (t *textWriter) Close() error {
+	_, err := fmt.Fprintf(t.w, "Successfully deserialized %d workflows \r\n", t.count)
+	return err
+}
+
+func (j *jsonlWriter) Write(workflow *exportpb.WorkflowExecution) error {
+	b, err := marshalCompactJSON(workflow, j.renderer)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, "%s\n", b)
+	return err
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+// Write emits the execution as an element of the items array so that the complete output is the compact JSON form
+// of a WorkflowExecutions message
+func (j *jsonWriter) Write(workflow *exportpb.WorkflowExecution) error {
+	b, err := marshalCompactJSON(workflow, j.renderer)
+	if err != nil {
+		return err
+	}
+	prefix := ","
+	if j.count == 0 {
+		prefix = `{"items":[`
+	}
+	j.count++
+	_, err = fmt.Fprintf(j.w, "%s%s", prefix, b)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	if j.count == 0 {
+		_, err := fmt.Fprintln(j.w, "{}")
+		return err
+	}
+	_, err := fmt.Fprintln(j.w, "]}")
+	return err
+}
+
+func (c *csvWriter) Write(workflow *exportpb.WorkflowExecution) error {
+	info, err := export.GetExportedWorkflowExecutionInfo(workflow)
+	if err != nil {
+		return fmt.Errorf("error extracting workflow information: %w", err)
+	}
+
+	var closeTime string
+	if !info.CloseTime.IsZero() {
+		closeTime = formatTime(info.CloseTime)
+	}
+
+	return c.w.Write([]string{
+		info.WorkflowID,
+		info.RunID,
+		info.WorkflowType,
+		formatTime(info.StartTime),
+		closeTime,
+		info.Status.String(),
+		strconv.Itoa(info.EventCount),
+	})
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// marshalCompactJSON marshals the execution with protojson and strips the whitespace protojson inserts, so every
+// execution fits on a single stable line
+func marshalCompactJSON(workflow *exportpb.WorkflowExecution, renderer *export.PayloadRenderer) ([]byte, error) {
+	var b []byte
+	var err error
+	if renderer != nil {
+		b, err = renderer.MarshalWorkflow(context.Background(), workflow, "")
+	} else {
+		b, err = protojson.Marshal(workflow)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	"go.temporal.io/sdk/converter"
+)
+
+// payloadFlags holds the command line flags that control how payloads are decoded and rendered
+type payloadFlags struct {
+	decode         bool
+	codecs         stringList
+	codecEndpoint  string
+	codecNamespace string
+	codecAuth      string
+	codecHeaders   repeatedString
+	codecBatchSize int
+}
+
+func (f *payloadFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.decode, "decode-payloads", false, "render payloads as readable values instead of base64 encoded data")
+	fs.Var(&f.codecs, "codec", "payload codec applied before rendering payloads, may be repeated in decode order, supported codecs: zlib. Implies --decode-payloads")
+	fs.StringVar(&f.codecEndpoint, "codec-endpoint", "", "URL of a remote codec server used to decode payloads, applied after any --codec. Implies --decode-payloads")
+	fs.StringVar(&f.codecNamespace, "codec-namespace", "", "namespace sent to the codec server in the X-Namespace header")
+	fs.StringVar(&f.codecAuth, "codec-auth", "", "value of the Authorization header sent to the codec server, e.g. 'Bearer <token>'")
+	fs.Var(&f.codecHeaders, "codec-header", "additional 'Name=value' header sent to the codec server, may be repeated")
+	fs.IntVar(&f.codecBatchSize, "codec-batch-size", 1000, "maximum number of payloads sent to the codecs in a single call")
+}
+
+// build returns the renderer used to print payloads as readable values, or nil when payloads should be printed as is,
+// and the decoder that applies the configured codecs to the workflows of a file before they are printed, or nil when
+// no codecs are configured
+func (f *payloadFlags) build() (renderer, decoder *export.PayloadRenderer, err error) {
+	var codecs []converter.PayloadCodec
+	for _, name := range f.codecs {
+		switch name {
+		case "zlib":
+			codecs = append(codecs, converter.NewZlibCodec(converter.ZlibCodecOptions{}))
+		default:
+			return nil, nil, fmt.Errorf("unknown payload codec %q", name)
+		}
+	}
+
+	if f.codecEndpoint != "" {
+		headers := map[string]string{}
+		for _, header := range f.codecHeaders {
+			name, value, ok := strings.Cut(header, "=")
+			if !ok {
+				return nil, nil, fmt.Errorf("invalid --codec-header %q, expected Name=value", header)
+			}
+			headers[name] = value
+		}
+		codecs = append(codecs, export.NewCodecServerClient(export.CodecServerOptions{
+			Endpoint:      f.codecEndpoint,
+			Namespace:     f.codecNamespace,
+			Authorization: f.codecAuth,
+			Headers:       headers,
+		}))
+	}
+
+	if len(codecs) > 0 {
+		decoder = export.NewPayloadRenderer(export.PayloadRendererOptions{Codecs: codecs, BatchSize: f.codecBatchSize})
+	}
+	if f.decode || decoder != nil {
+		renderer = export.NewPayloadRenderer(export.PayloadRendererOptions{})
+	}
+	return renderer, decoder, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.temporal.io/sdk/converter"
+
+	"github.com/temporalio/cloud-samples-go/internal/exporttest"
+)
+
+func TestCodecHeaders(t *testing.T) {
+	var got http.Header
+	handler := converter.NewPayloadCodecHTTPHandler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	var flags payloadFlags
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.register(fs)
+	err := fs.Parse([]string{
+		"--codec-endpoint", server.URL,
+		"--codec-header", "Accept=application/json, text/plain",
+		"--codec-header", "X-Tenant=a=b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, decoder, err := flags.build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if _, err := decoder.DecodeWorkflows(context.Background(), exporttest.Workflows(t, 1)); err != nil {
+		t.Fatalf("DecodeWorkflows: %v", err)
+	}
+
+	for name, want := range map[string]string{"Accept": "application/json, text/plain", "X-Tenant": "a=b"} {
+		if got.Get(name) != want {
+			t.Errorf("header %s = %q, want %q", name, got.Get(name), want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// printCommand prints every selected execution in the given export files in the requested output format
+func printCommand(args []string) error {
+	fs := newFlagSet("exporttool", "exporttool [--output text|jsonl|json|csv] [--view full|timeline] [--decode-payloads] [filter flags] /path/to/export/file [/path/to/export/dir 'glob/*' ...]")
+	output := fs.String("output", outputText, fmt.Sprintf("output format, one of: %s", strings.Join(outputFormats, ", ")))
+	view := fs.String("view", viewFull, "how the text output format shows each execution, 'full' for the complete history or 'timeline' for one line per event")
+	var input inputFlags
+	input.register(fs)
+	var payloads payloadFlags
+	payloads.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	renderer, decoder, err := payloads.build()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	out, err := newOutputWriter(*output, os.Stdout, renderer)
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+	switch *view {
+	case viewFull:
+	case viewTimeline:
+		text, ok := out.(*textWriter)
+		if !ok {
+			return fmt.Errorf("error: --view %s is only supported with --output %s", viewTimeline, outputText)
+		}
+		if renderer != nil {
+			return fmt.Errorf("error: --view %s does not show payloads and cannot be combined with --decode-payloads or codec flags", viewTimeline)
+		}
+		text.timeline = true
+	default:
+		return fmt.Errorf("error: unknown view %q, must be %s or %s", *view, viewFull, viewTimeline)
+	}
+
+	if decoder == nil {
+		err = input.readWorkflows(fs.Args(), func(_ string, workflow *exportpb.WorkflowExecution) error {
+			return out.Write(workflow)
+		})
+	} else {
+		err = input.readWorkflowBatches(fs.Args(), func(path string, workflows []*exportpb.WorkflowExecution) error {
+			// Decode the payloads of large batches at once so a remote codec server receives few requests
+			workflows, err := decoder.DecodeWorkflows(context.Background(), workflows)
+			if err != nil {
+				return fmt.Errorf("error decoding payloads in %s: %w", path, err)
+			}
+			for _, workflow := range workflows {
+				if err := out.Write(workflow); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// redactCommand writes the selected executions to a new export file with sensitive data redacted according to a rules
+// file
+func redactCommand(args []string) error {
+	fs := newFlagSet("exporttool redact", "exporttool redact --rules rules.json --out redacted.export [filter flags] /path/to/export/file [...]")
+	rulesPath := fs.String("rules", "", "path of the JSON redaction rules file")
+	out := fs.String("out", "", "path of the redacted export file to write")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *rulesPath == "" || *out == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	rules, err := export.LoadRedactionRules(*rulesPath)
+	if err != nil {
+		return err
+	}
+	redactor, err := export.NewRedactor(rules)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer file.Close()
+
+	writer := export.NewWriter(file)
+	err = input.readWorkflows(fs.Args(), func(_ string, workflow *exportpb.WorkflowExecution) error {
+		return writer.Write(redactor.Redact(workflow))
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+
+	fmt.Printf("Redacted %d workflows to %s\r\n", writer.Count(), *out)
+	return nil
+}
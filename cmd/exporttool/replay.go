@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	"github.com/temporalio/cloud-samples-go/workflows"
+	exportpb "go.temporal.io/api/export/v1"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/worker"
+)
+
+// replayCommand replays the selected executions against the workflows registered by workflows.RegisterWorkflows and
+// reports every execution whose history is no longer compatible with the workflow code
+func replayCommand(args []string) error {
+	fs := newFlagSet("exporttool replay", "exporttool replay [--verbose] [filter flags] /path/to/export/file [...]")
+	verbose := fs.Bool("verbose", false, "print the SDK replay logs and every successfully replayed execution")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	replayer := worker.NewWorkflowReplayer()
+	workflows.RegisterWorkflows(replayer, workflows.NewWorkflows())
+
+	logger := log.NewStructuredLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if *verbose {
+		logger = nil
+	}
+
+	var replayed, failed int
+	err := input.readWorkflows(fs.Args(), func(path string, workflow *exportpb.WorkflowExecution) error {
+		result := export.Replay(replayer, logger, workflow)
+		replayed++
+		if result.Err != nil {
+			failed++
+			fmt.Printf("FAILED %s WorkflowID: %s, RunID: %s, WorkflowType: %s\r\n\t%v\r\n", path, result.WorkflowID, result.RunID, result.WorkflowType, result.Err)
+		} else if *verbose {
+			fmt.Printf("OK     %s WorkflowID: %s, RunID: %s, WorkflowType: %s\r\n", path, result.WorkflowID, result.RunID, result.WorkflowType)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Replayed %d workflows, %d failed\r\n", replayed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d workflows failed to replay", failed)
+	}
+	return nil
+}
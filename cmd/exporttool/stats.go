@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	enumspb "go.temporal.io/api/enums/v1"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// reportedStatuses are the workflow statuses shown in the stats report, in display order
+var reportedStatuses = []enumspb.WorkflowExecutionStatus{
+	enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT,
+	enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW,
+	enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+}
+
+// statsCommand prints aggregate statistics over the selected executions in the given export files
+func statsCommand(args []string) error {
+	fs := newFlagSet("exporttool stats", "exporttool stats [--top N] [filter flags] /path/to/export/file [...]")
+	top := fs.Int("top", 10, "number of activity types to show in the activity failure ranking")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	summarizer := export.NewSummarizer()
+	err := input.readWorkflows(fs.Args(), func(_ string, workflow *exportpb.WorkflowExecution) error {
+		summarizer.Add(workflow)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeSummary(os.Stdout, summarizer.Summary(), *top)
+}
+
+func writeSummary(w io.Writer, summary *export.Summary, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Executions: %d\n\n", summary.Executions)
+
+	fmt.Fprintln(tw, "STATUS\tCOUNT\tPERCENT")
+	for _, status := range reportedStatuses {
+		count := summary.Statuses[status]
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", status, count, percent(count, summary.Executions))
+	}
+	fmt.Fprintln(tw)
+
+	workflowTypes := make([]string, 0, len(summary.WorkflowTypes))
+	for workflowType := range summary.WorkflowTypes {
+		workflowTypes = append(workflowTypes, workflowType)
+	}
+	slices.Sort(workflowTypes)
+
+	fmt.Fprint(tw, "WORKFLOW TYPE\tTOTAL")
+	for _, status := range reportedStatuses {
+		fmt.Fprintf(tw, "\t%s", status)
+	}
+	fmt.Fprintln(tw, "\tDURATION P50\tP95\tP99\tMAX EVENTS")
+	for _, workflowType := range workflowTypes {
+		typeSummary := summary.WorkflowTypes[workflowType]
+		fmt.Fprintf(tw, "%s\t%d", workflowType, typeSummary.Executions)
+		for _, status := range reportedStatuses {
+			fmt.Fprintf(tw, "\t%d", typeSummary.Statuses[status])
+		}
+		d := typeSummary.Durations
+		fmt.Fprintf(tw, "\t%s\t%s\t%s\t%d\n", formatDuration(d.P50), formatDuration(d.P95), formatDuration(d.P99), typeSummary.HistoryLengths.Max)
+	}
+	fmt.Fprintln(tw)
+
+	d := summary.Durations
+	fmt.Fprintln(tw, "DURATION (CLOSED)\tP50\tP95\tP99\tMAX")
+	fmt.Fprintf(tw, "all workflows\t%s\t%s\t%s\t%s\n\n", formatDuration(d.P50), formatDuration(d.P95), formatDuration(d.P99), formatDuration(d.Max))
+
+	h := summary.HistoryLengths
+	fmt.Fprintln(tw, "HISTORY LENGTH\tP50\tP95\tP99\tMAX")
+	fmt.Fprintf(tw, "all workflows\t%d\t%d\t%d\t%d\n\n", h.P50, h.P95, h.P99, h.Max)
+
+	fmt.Fprintln(tw, "EVENTS\tCOUNT\tPERCENT")
+	lower := 1
+	for _, bucket := range summary.HistoryLengthBuckets {
+		label := fmt.Sprintf("%d-%d", lower, bucket.MaxEvents)
+		if bucket.MaxEvents == 0 {
+			label = fmt.Sprintf(">%d", lower-1)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", label, bucket.Count, percent(bucket.Count, summary.Executions))
+		lower = bucket.MaxEvents + 1
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "ACTIVITY TYPE\tFAILED\tTIMED OUT\tTOTAL")
+	for i, failures := range summary.ActivityFailures {
+		if i == top {
+			break
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", failures.ActivityType, failures.Failed, failures.TimedOut, failures.Failed+failures.TimedOut)
+	}
+
+	return tw.Flush()
+}
+
+func percent(count, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(100*float64(count)/float64(total), 'f', 1, 64) + "%"
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Millisecond).String()
+}
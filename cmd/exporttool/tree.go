@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// treeCommand prints the parent, child and continue-as-new relationships between the executions in the given export
+// files
+func treeCommand(args []string) error {
+	fs := newFlagSet("exporttool tree", "exporttool tree [--run-id runID] [filter flags] /path/to/export/file [...]")
+	runID := fs.String("run-id", "", "only print the tree containing the execution with this run ID")
+	var input inputFlags
+	input.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	graph := export.NewExecutionGraph()
+	err := input.readWorkflows(fs.Args(), func(path string, workflow *exportpb.WorkflowExecution) error {
+		if err := graph.Add(workflow); err != nil {
+			return fmt.Errorf("error reading workflow from %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	roots := graph.Roots()
+	if *runID != "" {
+		node := graph.Node(*runID)
+		if node == nil {
+			return fmt.Errorf("run ID %s not found", *runID)
+		}
+		roots = []*export.ExecutionNode{treeRoot(node)}
+	}
+	fmt.Print(export.FormatExecutionTree(roots))
+	return nil
+}
+
+// treeRoot returns the first run of the outermost ancestor of node
+func treeRoot(node *export.ExecutionNode) *export.ExecutionNode {
+	visited := map[*export.ExecutionNode]bool{}
+	for !visited[node] {
+		visited[node] = true
+		switch {
+		case node.Previous != nil:
+			node = node.Previous
+		case node.Parent != nil:
+			node = node.Parent
+		case node.ResetFrom != nil:
+			node = node.ResetFrom
+		default:
+			return node
+		}
+	}
+	return node
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/temporalio/cloud-samples-go/export"
+)
+
+// verifyCommand checks every execution in the given export files for structural problems. Every problem is reported
+// before the command fails, so a single bad record does not hide the rest
+func verifyCommand(args []string) error {
+	fs := newFlagSet("exporttool verify", "exporttool verify /path/to/export/file [...]")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	paths, err := expandPaths(fs.Args())
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+
+	verifier := export.NewVerifier()
+	var problems int
+	for _, path := range paths {
+		for _, problem := range verifyFile(verifier, path) {
+			problems++
+			fmt.Printf("%v\r\n", problem)
+		}
+	}
+
+	fmt.Printf("Verified %d workflows in %d files, found %d problems\r\n", verifier.Executions(), len(paths), problems)
+	if problems > 0 {
+		return fmt.Errorf("%d problems found", problems)
+	}
+	return nil
+}
+
+func verifyFile(verifier *export.Verifier, path string) []*export.Problem {
+	file, err := os.Open(path)
+	if err != nil {
+		return []*export.Problem{{Source: path, Message: err.Error()}}
+	}
+	defer file.Close()
+	return verifier.VerifyReader(path, file)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	"github.com/temporalio/cloud-samples-go/export/sqlite"
+	enumspb "go.temporal.io/api/enums/v1"
+	exportpb "go.temporal.io/api/export/v1"
+)
+
+// unsuccessfulStatuses are the close statuses whose executions are listed individually by the watch command
+var unsuccessfulStatuses = map[enumspb.WorkflowExecutionStatus]bool{
+	enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:     true,
+	enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT:  true,
+	enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED:   true,
+	enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED: true,
+}
+
+type (
+	// checkpoint records the export files the watch command has processed, so that every file is processed exactly
+	// once across restarts
+	checkpoint struct {
+		path  string
+		Files map[string]*checkpointEntry `json:"files"`
+	}
+
+	// checkpointEntry is the state of a single export file in the checkpoint
+	checkpointEntry struct {
+		Size        int64     `json:"size"`
+		ModTime     time.Time `json:"modTime"`
+		ProcessedAt time.Time `json:"processedAt"`
+		Workflows   int       `json:"workflows"`
+		// Error is set if the file could not be decoded. The file is retried once its size or modification time
+		// changes, for example when an interrupted download is repeated
+		Error string `json:"error,omitempty"`
+	}
+
+	// retryableError is a failure that does not depend on the content of an export file, such as a file that cannot
+	// be opened or a failed database load. Such files are left out of the checkpoint and retried on the next poll
+	retryableError struct {
+		err error
+	}
+
+	// watcher processes the export files that appear below a directory
+	watcher struct {
+		dir        string
+		settle     time.Duration
+		filter     export.Filter
+		checkpoint *checkpoint
+		// skip holds the absolute paths of the files the watcher writes itself, the checkpoint and the database, which
+		// are never processed when they lie in the watched directory
+		skip   map[string]bool
+		loader *sqlite.Loader
+		out    io.Writer
+	}
+)
+
+// watchCommand polls a directory for new export files, prints a summary of each new file and optionally loads it into
+// a SQLite database
+func watchCommand(args []string) error {
+	fs := newFlagSet("exporttool watch", "exporttool watch [--interval 30s] [--checkpoint file] [--sqlite out.db] [filter flags] /path/to/export/dir")
+	interval := fs.Duration("interval", 30*time.Second, "how often to look for new export files")
+	settle := fs.Duration("settle", 10*time.Second, "only process files that have not been modified for this long, so that files still being copied are skipped")
+	checkpointPath := fs.String("checkpoint", "exporttool-watch.json", "path of the file recording which export files were processed")
+	dbPath := fs.String("sqlite", "", "also load the executions of each new export file into this SQLite database")
+	once := fs.Bool("once", false, "process the export files that are present and exit instead of polling")
+	var filters filterFlags
+	filters.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", *interval)
+	}
+
+	filter, err := filters.build()
+	if err != nil {
+		return err
+	}
+	cp, err := loadCheckpoint(*checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	w := &watcher{dir: fs.Arg(0), settle: *settle, filter: filter, checkpoint: cp, skip: map[string]bool{}, out: os.Stdout}
+	if err := w.skipFiles(*checkpointPath, ".tmp"); err != nil {
+		return err
+	}
+	if *dbPath != "" {
+		// SQLite keeps a rollback journal or write-ahead log next to the database while writing
+		if err := w.skipFiles(*dbPath, "-journal", "-wal", "-shm"); err != nil {
+			return err
+		}
+		if w.loader, err = sqlite.Open(*dbPath); err != nil {
+			return err
+		}
+		defer w.loader.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := w.poll(ctx); err != nil || *once {
+		return err
+	}
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if err := w.poll(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// skipFiles adds path and the files next to it with the given suffixes to the files the watcher never processes
+func (w *watcher) skipFiles(path string, suffixes ...string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("error resolving path %s: %w", path, err)
+	}
+	w.skip[abs] = true
+	for _, suffix := range suffixes {
+		w.skip[abs+suffix] = true
+	}
+	return nil
+}
+
+// poll processes every settled export file below the watched directory that is not in the checkpoint yet
+func (w *watcher) poll(ctx context.Context) error {
+	paths, err := expandPaths([]string{w.dir})
+	if err != nil {
+		return fmt.Errorf("error reading directory: %w", err)
+	}
+
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if abs, err := filepath.Abs(path); err == nil && w.skip[abs] {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			// The file was removed since the directory was listed
+			continue
+		}
+		if time.Since(info.ModTime()) < w.settle {
+			continue
+		}
+		entry, ok := w.checkpoint.Files[path]
+		if ok && (entry.Error == "" || (entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime()))) {
+			continue
+		}
+
+		workflows, err := w.process(ctx, path)
+		if err != nil && ctx.Err() != nil {
+			// Interrupted, the file is processed again by the next run
+			return nil
+		}
+		processedAt := time.Now().UTC()
+		var retryable *retryableError
+		if errors.As(err, &retryable) {
+			fmt.Fprintf(w.out, "%s %s: %v, retrying on next poll\n", processedAt.Format(time.RFC3339), path, err)
+			continue
+		}
+
+		entry = &checkpointEntry{Size: info.Size(), ModTime: info.ModTime(), ProcessedAt: processedAt, Workflows: workflows}
+		if err != nil {
+			entry.Error = err.Error()
+			fmt.Fprintf(w.out, "%s %s: %v\n", processedAt.Format(time.RFC3339), path, err)
+		}
+		w.checkpoint.Files[path] = entry
+		if err := w.checkpoint.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process summarizes a single export file and loads it into the database, returning the number of selected executions
+func (w *watcher) process(ctx context.Context, path string) (int, error) {
+	var workflows int
+	var batch []*exportpb.WorkflowExecution
+	counts := map[enumspb.WorkflowExecutionStatus]int{}
+	var unsuccessful []*export.ExecutionInfo
+	err := readFile(path, w.filter, func(workflow *exportpb.WorkflowExecution) error {
+		info, err := export.GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			return err
+		}
+		workflows++
+		counts[info.Status]++
+		if unsuccessfulStatuses[info.Status] {
+			unsuccessful = append(unsuccessful, info)
+		}
+
+		if w.loader == nil {
+			return nil
+		}
+		batch = append(batch, workflow)
+		if len(batch) < workflowBatchSize {
+			return nil
+		}
+		err = w.load(ctx, batch)
+		batch = nil
+		return err
+	})
+	if err == nil && len(batch) > 0 {
+		err = w.load(ctx, batch)
+	}
+	var retryable *retryableError
+	var pathErr *fs.PathError
+	switch {
+	case errors.As(err, &retryable):
+		return 0, err
+	case errors.As(err, &pathErr):
+		return 0, &retryableError{fmt.Errorf("error reading file: %w", err)}
+	case err != nil:
+		return 0, fmt.Errorf("error extracting workflow histories: %w", err)
+	}
+
+	summary := []string{fmt.Sprintf("%d workflows", workflows)}
+	for _, status := range reportedStatuses {
+		if counts[status] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	fmt.Fprintf(w.out, "%s %s: %s\n", time.Now().UTC().Format(time.RFC3339), path, strings.Join(summary, ", "))
+	for _, info := range unsuccessful {
+		fmt.Fprintf(w.out, "  %s WorkflowID: %s, RunID: %s, WorkflowType: %s\n", info.Status, info.WorkflowID, info.RunID, info.WorkflowType)
+	}
+	return workflows, nil
+}
+
+// load loads a batch of executions into the database. Load errors are retryable since loading replaces any executions
+// already loaded from an earlier attempt
+func (w *watcher) load(ctx context.Context, workflows []*exportpb.WorkflowExecution) error {
+	if err := w.loader.Load(ctx, workflows); err != nil {
+		return &retryableError{fmt.Errorf("error loading workflows: %w", err)}
+	}
+	return nil
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// loadCheckpoint reads the checkpoint at path, returning an empty checkpoint if the file does not exist
+func loadCheckpoint(path string) (*checkpoint, error) {
+	cp := &checkpoint{path: path, Files: map[string]*checkpointEntry{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint %s: %w", path, err)
+	}
+	if cp.Files == nil {
+		cp.Files = map[string]*checkpointEntry{}
+	}
+	return cp, nil
+}
+
+// save writes the checkpoint to a temporary file and renames it into place, so that an interrupted save never leaves a
+// corrupt checkpoint behind
+func (c *checkpoint) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("error writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("error writing checkpoint: %w", err)
+	}
+	return nil
+}
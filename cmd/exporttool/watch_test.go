@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	"github.com/temporalio/cloud-samples-go/export/sqlite"
+	"github.com/temporalio/cloud-samples-go/internal/exporttest"
+)
+
+// newTestWatcher returns a watcher for dir that keeps its checkpoint and database in dir, as a careless setup would
+func newTestWatcher(t *testing.T, dir string) (*watcher, *bytes.Buffer) {
+	t.Helper()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+	cp, err := loadCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	w := &watcher{dir: dir, filter: export.MatchAll(), checkpoint: cp, skip: map[string]bool{}, out: &out}
+	if err := w.skipFiles(checkpointPath, ".tmp"); err != nil {
+		t.Fatal(err)
+	}
+	dbPath := filepath.Join(dir, "out.db")
+	if err := w.skipFiles(dbPath, "-journal", "-wal", "-shm"); err != nil {
+		t.Fatal(err)
+	}
+	if w.loader, err = sqlite.Open(dbPath); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { w.loader.Close() })
+	return w, &out
+}
+
+// settle sets the modification time of path to an hour ago
+func settle(t *testing.T, path string) {
+	t.Helper()
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchProcessesFilesOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.bin")
+	exporttest.WriteFile(t, path, exporttest.Workflows(t, 20))
+	settle(t, path)
+
+	w, out := newTestWatcher(t, dir)
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if !strings.Contains(out.String(), path+": 20 workflows") {
+		t.Errorf("output does not summarize the export file:\n%s", out)
+	}
+	if entry := w.checkpoint.Files[path]; entry == nil || entry.Workflows != 20 || entry.Error != "" {
+		t.Errorf("checkpoint entry = %+v, want 20 workflows", entry)
+	}
+	if len(w.checkpoint.Files) != 1 {
+		t.Errorf("checkpoint has %d files, want only the export file", len(w.checkpoint.Files))
+	}
+
+	// A second poll, and a watcher restarted from the saved checkpoint, skip the processed file
+	out.Reset()
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	restarted, restartedOut := newTestWatcher(t, dir)
+	if entry := restarted.checkpoint.Files[path]; entry == nil || entry.Workflows != 20 {
+		t.Errorf("reloaded checkpoint entry = %+v, want 20 workflows", entry)
+	}
+	if err := restarted.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if out.Len() > 0 || restartedOut.Len() > 0 {
+		t.Errorf("processed file was processed again:\n%s%s", out, restartedOut)
+	}
+}
+
+func TestWatchSkipsUnsettledFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.bin")
+	exporttest.WriteFile(t, path, exporttest.Workflows(t, 5))
+
+	w, out := newTestWatcher(t, dir)
+	w.settle = time.Minute
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if out.Len() > 0 || len(w.checkpoint.Files) > 0 {
+		t.Fatalf("file still being written was processed:\n%s", out)
+	}
+
+	settle(t, path)
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if entry := w.checkpoint.Files[path]; entry == nil || entry.Workflows != 5 {
+		t.Errorf("checkpoint entry = %+v, want 5 workflows once the file settled", entry)
+	}
+}
+
+func TestWatchDecodeError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.bin")
+	if err := os.WriteFile(path, []byte{0x0a, 0xff, 0x01}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settle(t, path)
+
+	w, out := newTestWatcher(t, dir)
+	for range 2 {
+		if err := w.poll(context.Background()); err != nil {
+			t.Fatalf("poll: %v", err)
+		}
+	}
+	entry := w.checkpoint.Files[path]
+	if entry == nil || !strings.Contains(entry.Error, "error extracting workflow histories") {
+		t.Fatalf("checkpoint entry = %+v, want the decode error", entry)
+	}
+	if n := strings.Count(out.String(), path); n != 1 {
+		t.Errorf("undecodable file was reported %d times, want once until it changes:\n%s", n, out)
+	}
+
+	// The file is retried once it changes
+	exporttest.WriteFile(t, path, exporttest.Workflows(t, 5))
+	if err := os.Chtimes(path, time.Now().Add(-30*time.Minute), time.Now().Add(-30*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if entry := w.checkpoint.Files[path]; entry == nil || entry.Error != "" || entry.Workflows != 5 {
+		t.Errorf("checkpoint entry = %+v, want 5 workflows after the file was replaced", entry)
+	}
+}
+
+func TestWatchRetryableError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.bin")
+	exporttest.WriteFile(t, path, exporttest.Workflows(t, 5))
+	settle(t, path)
+
+	w, out := newTestWatcher(t, dir)
+	loader := w.loader
+	closed, err := sqlite.Open(filepath.Join(t.TempDir(), "closed.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+	w.loader = closed
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if !strings.Contains(out.String(), "retrying on next poll") {
+		t.Errorf("output does not report the retry:\n%s", out)
+	}
+	if entry, ok := w.checkpoint.Files[path]; ok {
+		t.Fatalf("file that failed to load was checkpointed: %+v", entry)
+	}
+
+	w.loader = loader
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if entry := w.checkpoint.Files[path]; entry == nil || entry.Workflows != 5 || entry.Error != "" {
+		t.Errorf("checkpoint entry = %+v, want 5 workflows after the retry", entry)
+	}
+}
+
+func TestWatchCommandInterval(t *testing.T) {
+	dir := t.TempDir()
+	err := watchCommand([]string{"--interval", "0", "--once", "--checkpoint", filepath.Join(dir, "checkpoint.json"), dir})
+	if err == nil || !strings.Contains(err.Error(), "--interval") {
+		t.Errorf("watchCommand() = %v, want an --interval error", err)
+	}
+}
@@ -0,0 +1,191 @@
+package export
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/api/failure/v1"
+)
+
+const (
+	// ActivityScheduled is an activity that was scheduled but has not started
+	ActivityScheduled ActivityStatus = iota
+	// ActivityStarted is an activity that started but has not closed
+	ActivityStarted
+	ActivityCompleted
+	ActivityFailed
+	ActivityTimedOut
+	ActivityCanceled
+)
+
+// ActivityStatuses lists every activity status in display order
+var ActivityStatuses = []ActivityStatus{ActivityScheduled, ActivityStarted, ActivityCompleted, ActivityFailed, ActivityTimedOut, ActivityCanceled}
+
+type (
+	// ActivityStatus is the state of an activity at the end of a history
+	ActivityStatus int
+
+	// ActivityRun is a single activity stitched together from its scheduled, started and close events. The server only
+	// records the started event of the final attempt, so the times of earlier attempts are not available
+	ActivityRun struct {
+		WorkflowID       string
+		RunID            string
+		ScheduledEventID int64
+		ActivityID       string
+		ActivityType     string
+		TaskQueue        string
+		Status           ActivityStatus
+		// Attempt is the attempt number of the final attempt, 0 if the activity never started
+		Attempt       int32
+		ScheduledTime time.Time
+		// StartedTime and CloseTime are zero if the activity did not start or close
+		StartedTime time.Time
+		CloseTime   time.Time
+		// QueueLatency is the time from scheduling to the start of the final attempt, which includes the time spent on
+		// earlier attempts and retry backoff
+		QueueLatency time.Duration
+		// ExecutionTime is the time from the start of the final attempt to the close event
+		ExecutionTime time.Duration
+		// Failure is the failure of a failed or timed out activity, or the last failure of an activity that was retried
+		Failure *failure.Failure
+	}
+
+	// ActivityTypeSummary aggregates the runs of a single activity type
+	ActivityTypeSummary struct {
+		ActivityType string
+		Runs         int
+		Statuses     map[ActivityStatus]int
+		// Retried counts the runs that needed more than one attempt
+		Retried       int
+		Attempts      Percentiles[int32]
+		QueueLatency  Percentiles[time.Duration]
+		ExecutionTime Percentiles[time.Duration]
+	}
+)
+
+func (s ActivityStatus) String() string {
+	switch s {
+	case ActivityScheduled:
+		return "Scheduled"
+	case ActivityStarted:
+		return "Started"
+	case ActivityCompleted:
+		return "Completed"
+	case ActivityFailed:
+		return "Failed"
+	case ActivityTimedOut:
+		return "TimedOut"
+	case ActivityCanceled:
+		return "Canceled"
+	}
+	return "Unknown"
+}
+
+// ActivityRuns returns the activities of an exported workflow execution in the order they were scheduled
+func ActivityRuns(workflow *export.WorkflowExecution) []*ActivityRun {
+	events := workflow.GetHistory().GetEvents()
+	// Use the reset-aware run ID so that the activities of a reset run are attributed to the reset run
+	var workflowID, runID string
+	if info, err := GetExportedWorkflowExecutionInfo(workflow); err == nil {
+		workflowID, runID = info.WorkflowID, info.RunID
+	}
+
+	var runs []*ActivityRun
+	byScheduledEventID := map[int64]*ActivityRun{}
+	closeRun := func(scheduledEventID int64, status ActivityStatus, closeTime time.Time, f *failure.Failure) {
+		run := byScheduledEventID[scheduledEventID]
+		if run == nil {
+			return
+		}
+		run.Status, run.CloseTime = status, closeTime
+		if f != nil {
+			run.Failure = f
+		}
+		if !run.StartedTime.IsZero() {
+			run.ExecutionTime = closeTime.Sub(run.StartedTime)
+		}
+	}
+
+	for _, event := range events {
+		eventTime := event.GetEventTime().AsTime()
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			a := event.GetActivityTaskScheduledEventAttributes()
+			run := &ActivityRun{
+				WorkflowID:       workflowID,
+				RunID:            runID,
+				ScheduledEventID: event.GetEventId(),
+				ActivityID:       a.GetActivityId(),
+				ActivityType:     a.GetActivityType().GetName(),
+				TaskQueue:        a.GetTaskQueue().GetName(),
+				Status:           ActivityScheduled,
+				ScheduledTime:    eventTime,
+			}
+			runs = append(runs, run)
+			byScheduledEventID[event.GetEventId()] = run
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+			a := event.GetActivityTaskStartedEventAttributes()
+			if run := byScheduledEventID[a.GetScheduledEventId()]; run != nil {
+				run.Status, run.StartedTime, run.Attempt = ActivityStarted, eventTime, a.GetAttempt()
+				run.QueueLatency = eventTime.Sub(run.ScheduledTime)
+				run.Failure = a.GetLastFailure()
+			}
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			closeRun(event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId(), ActivityCompleted, eventTime, nil)
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			a := event.GetActivityTaskFailedEventAttributes()
+			closeRun(a.GetScheduledEventId(), ActivityFailed, eventTime, a.GetFailure())
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			a := event.GetActivityTaskTimedOutEventAttributes()
+			closeRun(a.GetScheduledEventId(), ActivityTimedOut, eventTime, a.GetFailure())
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+			closeRun(event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(), ActivityCanceled, eventTime, nil)
+		}
+	}
+	return runs
+}
+
+// SummarizeActivities aggregates activity runs by activity type, ordered by activity type
+func SummarizeActivities(runs []*ActivityRun) []*ActivityTypeSummary {
+	type accumulator struct {
+		summary       *ActivityTypeSummary
+		attempts      []int32
+		queueLatency  []time.Duration
+		executionTime []time.Duration
+	}
+	byType := map[string]*accumulator{}
+	for _, run := range runs {
+		acc, ok := byType[run.ActivityType]
+		if !ok {
+			acc = &accumulator{summary: &ActivityTypeSummary{ActivityType: run.ActivityType, Statuses: map[ActivityStatus]int{}}}
+			byType[run.ActivityType] = acc
+		}
+		acc.summary.Runs++
+		acc.summary.Statuses[run.Status]++
+		if run.Attempt > 1 {
+			acc.summary.Retried++
+		}
+		if !run.StartedTime.IsZero() {
+			acc.attempts = append(acc.attempts, run.Attempt)
+			acc.queueLatency = append(acc.queueLatency, run.QueueLatency)
+		}
+		if !run.StartedTime.IsZero() && !run.CloseTime.IsZero() {
+			acc.executionTime = append(acc.executionTime, run.ExecutionTime)
+		}
+	}
+
+	summaries := make([]*ActivityTypeSummary, 0, len(byType))
+	for _, acc := range byType {
+		acc.summary.Attempts = computePercentiles(acc.attempts)
+		acc.summary.QueueLatency = computePercentiles(acc.queueLatency)
+		acc.summary.ExecutionTime = computePercentiles(acc.executionTime)
+		summaries = append(summaries, acc.summary)
+	}
+	slices.SortFunc(summaries, func(a, b *ActivityTypeSummary) int {
+		return cmp.Compare(a.ActivityType, b.ActivityType)
+	})
+	return summaries
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+func TestActivityRuns(t *testing.T) {
+	var retried, failed int
+	for _, workflow := range generateWorkflows(t, 200) {
+		info, err := GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scheduled := 0
+		for range ActivityTaskScheduledEvents(workflow) {
+			scheduled++
+		}
+		runs := ActivityRuns(workflow)
+		if len(runs) != scheduled {
+			t.Fatalf("workflow %s has %d activity runs, want %d", info.WorkflowID, len(runs), scheduled)
+		}
+
+		for _, run := range runs {
+			if run.WorkflowID != info.WorkflowID || run.RunID != info.RunID {
+				t.Errorf("activity %s belongs to %s/%s, want %s/%s", run.ActivityID, run.WorkflowID, run.RunID, info.WorkflowID, info.RunID)
+			}
+			switch run.Status {
+			case ActivityCompleted:
+				if run.Attempt > 1 {
+					retried++
+					if run.Failure == nil {
+						t.Errorf("retried activity %s has no last failure", run.ActivityID)
+					}
+				}
+			case ActivityFailed, ActivityTimedOut:
+				failed++
+				if run.Failure == nil {
+					t.Errorf("%s activity %s has no failure", run.Status, run.ActivityID)
+				}
+			}
+			if !run.CloseTime.IsZero() && run.CloseTime.Before(run.ScheduledTime) {
+				t.Errorf("activity %s closed before it was scheduled", run.ActivityID)
+			}
+		}
+	}
+	if retried == 0 || failed == 0 {
+		t.Fatalf("generated executions have %d retried and %d failed activities, want both", retried, failed)
+	}
+}
+
+func TestActivityRunsReset(t *testing.T) {
+	base := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	runs := ActivityRuns(resetWorkflow(t, base, "reset-run"))
+	if len(runs) == 0 {
+		t.Fatal("reset run has no activities")
+	}
+	for _, run := range runs {
+		if run.RunID != "reset-run" {
+			t.Errorf("activity %s of the reset run has run ID %s", run.ActivityID, run.RunID)
+		}
+	}
+}
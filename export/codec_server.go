@@ -0,0 +1,48 @@
+package export
+
+import (
+	"net/http"
+	"strings"
+
+	"go.temporal.io/sdk/converter"
+)
+
+// CodecServerOptions configure a client for a remote codec server implementing Temporal's codec server HTTP API
+type CodecServerOptions struct {
+	// Endpoint is the base URL of the codec server, the /decode path is appended to it
+	Endpoint string
+	// Namespace is sent in the X-Namespace header, codec servers commonly use it to select encryption keys
+	Namespace string
+	// Authorization is sent as the Authorization header, for example "Bearer <token>"
+	Authorization string
+	// Headers are additional headers sent with every request
+	Headers map[string]string
+	// HTTPClient is the client used to call the codec server, defaults to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// NewCodecServerClient returns a PayloadCodec that decodes payloads by calling a remote codec server. Use it as one of
+// the PayloadRendererOptions.Codecs to render payloads that were encrypted with keys only the codec server holds
+func NewCodecServerClient(options CodecServerOptions) converter.PayloadCodec {
+	client := http.DefaultClient
+	if options.HTTPClient != nil {
+		client = options.HTTPClient
+	}
+
+	return converter.NewRemotePayloadCodec(converter.RemotePayloadCodecOptions{
+		Endpoint: strings.TrimSuffix(options.Endpoint, "/"),
+		Client:   *client,
+		ModifyRequest: func(req *http.Request) error {
+			if options.Namespace != "" {
+				req.Header.Set("X-Namespace", options.Namespace)
+			}
+			if options.Authorization != "" {
+				req.Header.Set("Authorization", options.Authorization)
+			}
+			for k, v := range options.Headers {
+				req.Header.Set(k, v)
+			}
+			return nil
+		},
+	})
+}
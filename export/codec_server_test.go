@@ -0,0 +1,163 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/api/proxy"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// codecStub is a codec server that decodes zlib encoded payloads and records the requests it receives
+type codecStub struct {
+	handler http.Handler
+	headers []http.Header
+	batches []int
+}
+
+func (s *codecStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var payloads common.Payloads
+	if err := protojson.Unmarshal(body, &payloads); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.headers = append(s.headers, r.Header.Clone())
+	s.batches = append(s.batches, len(payloads.GetPayloads()))
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	s.handler.ServeHTTP(w, r)
+}
+
+// encodeWorkflows returns copies of workflows with every payload except search attributes zlib encoded, and the
+// number of encoded payloads
+func encodeWorkflows(t *testing.T, workflows []*export.WorkflowExecution) ([]*export.WorkflowExecution, int) {
+	t.Helper()
+	codec := converter.NewZlibCodec(converter.ZlibCodecOptions{AlwaysEncode: true})
+	var count int
+	options := proxy.VisitPayloadsOptions{
+		SkipSearchAttributes: true,
+		Visitor: func(_ *proxy.VisitPayloadsContext, p []*common.Payload) ([]*common.Payload, error) {
+			count += len(p)
+			return codec.Encode(p)
+		},
+	}
+	encoded := make([]*export.WorkflowExecution, len(workflows))
+	for i, workflow := range workflows {
+		encoded[i] = proto.Clone(workflow).(*export.WorkflowExecution)
+		if err := proxy.VisitPayloads(context.Background(), encoded[i], options); err != nil {
+			t.Fatalf("failed to encode payloads: %v", err)
+		}
+	}
+	return encoded, count
+}
+
+func TestCodecServerClientDecode(t *testing.T) {
+	stub := &codecStub{handler: converter.NewPayloadCodecHTTPHandler(converter.NewZlibCodec(converter.ZlibCodecOptions{}))}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	client := NewCodecServerClient(CodecServerOptions{
+		Endpoint:      server.URL + "/",
+		Namespace:     "test.a1b2c",
+		Authorization: "Bearer token",
+		Headers:       map[string]string{"X-Test": "value"},
+	})
+	renderer := NewPayloadRenderer(PayloadRendererOptions{Codecs: []converter.PayloadCodec{client}})
+
+	workflow := generateWorkflows(t, 1)[0]
+	encoded, count := encodeWorkflows(t, []*export.WorkflowExecution{workflow})
+	if proto.Equal(encoded[0], workflow) {
+		t.Fatal("payloads were not encoded")
+	}
+
+	decoded, err := renderer.DecodePayloads(context.Background(), encoded[0])
+	if err != nil {
+		t.Fatalf("DecodePayloads: %v", err)
+	}
+	if !proto.Equal(decoded, workflow) {
+		t.Error("decoded workflow differs from the original")
+	}
+	if len(stub.batches) != 1 || stub.batches[0] != count {
+		t.Errorf("codec server received batches %v, want one batch of %d payloads", stub.batches, count)
+	}
+	for name, want := range map[string]string{"X-Namespace": "test.a1b2c", "Authorization": "Bearer token", "X-Test": "value"} {
+		if got := stub.headers[0].Get(name); got != want {
+			t.Errorf("header %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestDecodeWorkflowsBatches(t *testing.T) {
+	stub := &codecStub{handler: converter.NewPayloadCodecHTTPHandler(converter.NewZlibCodec(converter.ZlibCodecOptions{}))}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	const batchSize = 25
+	renderer := NewPayloadRenderer(PayloadRendererOptions{
+		Codecs:    []converter.PayloadCodec{NewCodecServerClient(CodecServerOptions{Endpoint: server.URL})},
+		BatchSize: batchSize,
+	})
+
+	workflows := generateWorkflows(t, 20)
+	encoded, count := encodeWorkflows(t, workflows)
+	if count <= batchSize {
+		t.Fatalf("generated workflows have %d payloads, want more than one batch", count)
+	}
+	decoded, err := renderer.DecodeWorkflows(context.Background(), encoded)
+	if err != nil {
+		t.Fatalf("DecodeWorkflows: %v", err)
+	}
+	for i := range workflows {
+		if !proto.Equal(decoded[i], workflows[i]) {
+			t.Errorf("decoded workflow %d differs from the original", i)
+		}
+	}
+
+	if want := (count + batchSize - 1) / batchSize; len(stub.batches) != want {
+		t.Errorf("codec server received %d requests for %d payloads, want %d", len(stub.batches), count, want)
+	}
+	var total int
+	for _, batch := range stub.batches {
+		if batch > batchSize {
+			t.Errorf("codec server received a batch of %d payloads, want at most %d", batch, batchSize)
+		}
+		total += batch
+	}
+	if total != count {
+		t.Errorf("codec server received %d payloads, want %d", total, count)
+	}
+}
+
+func TestCodecServerClientErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "namespace not allowed", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	renderer := NewPayloadRenderer(PayloadRendererOptions{
+		Codecs: []converter.PayloadCodec{NewCodecServerClient(CodecServerOptions{Endpoint: server.URL})},
+	})
+	encoded, _ := encodeWorkflows(t, generateWorkflows(t, 1))
+	_, err := renderer.DecodeWorkflows(context.Background(), encoded)
+	if err == nil {
+		t.Fatal("DecodeWorkflows succeeded against a failing codec server")
+	}
+	for _, want := range []string{"failed to decode payloads", http.StatusText(http.StatusForbidden), "namespace not allowed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
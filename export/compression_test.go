@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func gzipCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstdCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encoder.Close()
+	return encoder.EncodeAll(data, nil)
+}
+
+func TestCompressedExports(t *testing.T) {
+	workflows := generateWorkflows(t, 25)
+	data := serializeWorkflows(t, workflows)
+
+	inputs := map[string][]byte{
+		"uncompressed": data,
+		"gzip":         gzipCompress(t, data),
+		"zstd":         zstdCompress(t, data),
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			got, err := readAll(input)
+			if err != nil {
+				t.Fatalf("Next: %v", err)
+			}
+			assertWorkflowsEqual(t, got, workflows)
+
+			deserialized, err := DeserializeExportedWorkflows(input)
+			if err != nil {
+				t.Fatalf("DeserializeExportedWorkflows: %v", err)
+			}
+			assertWorkflowsEqual(t, deserialized.GetItems(), workflows)
+
+			r, err := Decompress(bytes.NewReader(input))
+			if err != nil {
+				t.Fatalf("Decompress: %v", err)
+			}
+			defer r.Close()
+			decompressed, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("failed to read decompressed export: %v", err)
+			}
+			if !bytes.Equal(decompressed, data) {
+				t.Error("decompressed export differs from the original")
+			}
+		})
+	}
+}
+
+func TestTruncatedCompressedExports(t *testing.T) {
+	data := serializeWorkflows(t, generateWorkflows(t, 5))
+	inputs := map[string][]byte{
+		"gzip": gzipCompress(t, data),
+		"zstd": zstdCompress(t, data),
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			if _, err := readAll(input[:len(input)/2]); err == nil {
+				t.Error("reading a truncated export succeeded")
+			}
+			if _, err := DeserializeExportedWorkflows(input[:len(input)/2]); err == nil {
+				t.Error("deserializing a truncated export succeeded")
+			}
+		})
+	}
+
+	if _, err := readAll(gzipCompress(t, data)[:len(gzipMagic)+1]); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("reading a truncated gzip header returned error %v", err)
+	}
+}
@@ -9,10 +9,17 @@ import (
 	"go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
 )
 
 // DeserializeExportedWorkflows deserializes a byte array into a WorkflowExecutions object. This is useful for programmatically processing workflow histories
+// Gzip and zstd compressed exports are decompressed automatically
 func DeserializeExportedWorkflows(bytes []byte) (*export.WorkflowExecutions, error) {
+	bytes, err := decompressBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+
 	blob := &common.DataBlob{
 		EncodingType: enumspb.ENCODING_TYPE_PROTO3, // Currently workflow histories are only exported in proto3 format
 		Data:         bytes,
@@ -35,23 +42,42 @@ func FormatWorkflow(workflow *export.WorkflowExecution) string {
 	return pbMarshaler.Format(workflow)
 }
 
+// MarshalHistoryJSON marshals the history of an exported workflow execution to the temporal.api.history.v1.History JSON
+// read by the Temporal CLI, the SDK replayer's ReplayWorkflowHistoryFromJSONFile and client.HistoryFromJSON
+func MarshalHistoryJSON(workflow *export.WorkflowExecution) ([]byte, error) {
+	if workflow.GetHistory() == nil {
+		return nil, fmt.Errorf("workflow history is nil")
+	}
+	return protojson.MarshalOptions{Indent: "  "}.Marshal(workflow.GetHistory())
+}
+
 // GetExportedWorkflowInformation returns a string containing the workflow ID, run ID, and workflow type
 func GetExportedWorkflowInformation(workflow *export.WorkflowExecution) (string, error) {
+	startAttributes, err := GetWorkflowStartedEventAttributes(workflow)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("WorkflowID: %s, RunID: %s, WorkflowType: %s", startAttributes.GetWorkflowId(), startAttributes.GetOriginalExecutionRunId(), startAttributes.GetWorkflowType().GetName()), nil
+}
+
+// GetWorkflowStartedEventAttributes returns the attributes of the WorkflowExecutionStarted event that opens the workflow history
+func GetWorkflowStartedEventAttributes(workflow *export.WorkflowExecution) (*historypb.WorkflowExecutionStartedEventAttributes, error) {
 	history := workflow.GetHistory()
 	if history == nil {
-		return "", fmt.Errorf("workflow history is nil")
+		return nil, fmt.Errorf("workflow history is nil")
 	}
 
 	events := history.GetEvents()
 	if len(events) == 0 {
-		return "", fmt.Errorf("workflow history has no events")
+		return nil, fmt.Errorf("workflow history has no events")
 	}
 
 	firstEvent := events[0]
 	startAttributes := firstEvent.GetWorkflowExecutionStartedEventAttributes()
 	if startAttributes == nil {
-		return "", fmt.Errorf("first workflow history is not a start event")
+		return nil, fmt.Errorf("first workflow history is not a start event")
 	}
 
-	return fmt.Sprintf("WorkflowID: %s, RunID: %s, WorkflowType: %s", startAttributes.GetWorkflowId(), startAttributes.GetOriginalExecutionRunId(), startAttributes.GetWorkflowType().GetName()), nil
+	return startAttributes, nil
 }
@@ -0,0 +1,30 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMarshalHistoryJSON(t *testing.T) {
+	for i, workflow := range generateWorkflows(t, 20) {
+		data, err := MarshalHistoryJSON(workflow)
+		if err != nil {
+			t.Fatalf("MarshalHistoryJSON: %v", err)
+		}
+		history, err := client.HistoryFromJSON(bytes.NewReader(data), client.HistoryJSONOptions{})
+		if err != nil {
+			t.Fatalf("HistoryFromJSON: %v", err)
+		}
+		if !proto.Equal(history, workflow.GetHistory()) {
+			t.Errorf("history %d differs after a round trip through client.HistoryFromJSON", i)
+		}
+	}
+
+	if _, err := MarshalHistoryJSON(&export.WorkflowExecution{}); err == nil {
+		t.Error("MarshalHistoryJSON accepted an execution without a history")
+	}
+}
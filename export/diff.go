@@ -0,0 +1,300 @@
+package export
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/temporalproto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// timestampMessage is the full name of the well known timestamp message, whose fields are ignored by default
+const timestampMessage protoreflect.FullName = "google.protobuf.Timestamp"
+
+// atomicMessages are compared and reported as a whole rather than field by field, so that a changed payload shows its
+// value rather than base64 data and a changed duration is not split into seconds and nanos
+var atomicMessages = map[protoreflect.FullName]bool{
+	timestampMessage:                 true,
+	"google.protobuf.Duration":       true,
+	"temporal.api.common.v1.Payload": true,
+}
+
+// DefaultIgnoredFields are the fields DiffHistories ignores unless DiffOptions.IgnoredFields is set. They hold values that
+// differ between otherwise identical executions, such as internal task IDs, worker identities and run IDs
+var DefaultIgnoredFields = []string{
+	"task_id",
+	"version",
+	"identity",
+	"request_id",
+	"run_id",
+	"original_execution_run_id",
+	"first_execution_run_id",
+	"continued_execution_run_id",
+	"new_execution_run_id",
+	"new_run_id",
+}
+
+type (
+	// DiffOptions configure DiffHistories
+	DiffOptions struct {
+		// IgnoredFields are the names of fields, in snake case as in the proto definitions, that are not compared
+		// wherever they appear. Defaults to DefaultIgnoredFields when nil
+		IgnoredFields []string
+		// CompareTimestamps compares timestamp fields such as event times, which are ignored by default
+		CompareTimestamps bool
+	}
+
+	// EventDiffKind describes how an event differs between two histories
+	EventDiffKind int
+
+	// EventDiff is a difference between the events with the same ID in two histories
+	EventDiff struct {
+		Kind    EventDiffKind
+		EventID int64
+		// Left and Right are the events in the first and second history, Left is nil for added events and Right is nil
+		// for removed events
+		Left  *historypb.HistoryEvent
+		Right *historypb.HistoryEvent
+		// Fields are the attribute level differences of a changed event
+		Fields []*FieldDiff
+	}
+
+	// FieldDiff is a difference in a single field of an event
+	FieldDiff struct {
+		// Path is the dotted path of the field from the event, for example
+		// "activity_task_scheduled_event_attributes.activity_type.name". List elements and map entries are written as
+		// [index] and [key]
+		Path string
+		// Left and Right are the formatted values, "<unset>" when the field is not set on that side
+		Left  string
+		Right string
+	}
+)
+
+const (
+	// EventAdded is an event only present in the second history
+	EventAdded EventDiffKind = iota
+	// EventRemoved is an event only present in the first history
+	EventRemoved
+	// EventChanged is an event of the same type in both histories whose attributes differ
+	EventChanged
+)
+
+const unsetValue = "<unset>"
+
+func (k EventDiffKind) String() string {
+	switch k {
+	case EventAdded:
+		return "added"
+	case EventRemoved:
+		return "removed"
+	case EventChanged:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// DiffHistories compares two workflow histories event by event and returns their differences ordered by event ID.
+// Events are aligned by ID, an event whose type differs between the histories is reported as removed from the first
+// history and added to the second
+func DiffHistories(left, right *historypb.History, options DiffOptions) []*EventDiff {
+	ignored := options.IgnoredFields
+	if ignored == nil {
+		ignored = DefaultIgnoredFields
+	}
+	d := &differ{ignored: map[string]bool{}, compareTimestamps: options.CompareTimestamps}
+	for _, name := range ignored {
+		d.ignored[name] = true
+	}
+
+	leftEvents := eventsByID(left)
+	rightEvents := eventsByID(right)
+	var ids []int64
+	for id := range leftEvents {
+		ids = append(ids, id)
+	}
+	for id := range rightEvents {
+		if _, ok := leftEvents[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	var diffs []*EventDiff
+	for _, id := range ids {
+		l, r := leftEvents[id], rightEvents[id]
+		switch {
+		case r == nil:
+			diffs = append(diffs, &EventDiff{Kind: EventRemoved, EventID: id, Left: l})
+		case l == nil:
+			diffs = append(diffs, &EventDiff{Kind: EventAdded, EventID: id, Right: r})
+		case l.GetEventType() != r.GetEventType():
+			diffs = append(diffs,
+				&EventDiff{Kind: EventRemoved, EventID: id, Left: l},
+				&EventDiff{Kind: EventAdded, EventID: id, Right: r})
+		default:
+			if fields := d.diffMessage("", l.ProtoReflect(), r.ProtoReflect()); len(fields) > 0 {
+				diffs = append(diffs, &EventDiff{Kind: EventChanged, EventID: id, Left: l, Right: r, Fields: fields})
+			}
+		}
+	}
+	return diffs
+}
+
+func eventsByID(history *historypb.History) map[int64]*historypb.HistoryEvent {
+	events := make(map[int64]*historypb.HistoryEvent, len(history.GetEvents()))
+	for _, event := range history.GetEvents() {
+		events[event.GetEventId()] = event
+	}
+	return events
+}
+
+type differ struct {
+	ignored           map[string]bool
+	compareTimestamps bool
+}
+
+func (d *differ) diffMessage(path string, l, r protoreflect.Message) []*FieldDiff {
+	var diffs []*FieldDiff
+	fields := l.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if d.ignored[string(fd.Name())] || (!d.compareTimestamps && fd.Message() != nil && fd.Message().FullName() == timestampMessage) {
+			continue
+		}
+		if !l.Has(fd) && !r.Has(fd) {
+			continue
+		}
+
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		switch {
+		case fd.IsList():
+			diffs = append(diffs, d.diffList(fieldPath, fd, l.Get(fd).List(), r.Get(fd).List())...)
+		case fd.IsMap():
+			diffs = append(diffs, d.diffMap(fieldPath, fd, l.Get(fd).Map(), r.Get(fd).Map())...)
+		case !l.Has(fd) || !r.Has(fd):
+			diffs = append(diffs, &FieldDiff{Path: fieldPath, Left: formatField(fd, l), Right: formatField(fd, r)})
+		default:
+			diffs = append(diffs, d.diffValue(fieldPath, fd, l.Get(fd), r.Get(fd))...)
+		}
+	}
+	return diffs
+}
+
+func (d *differ) diffList(path string, fd protoreflect.FieldDescriptor, l, r protoreflect.List) []*FieldDiff {
+	var diffs []*FieldDiff
+	for i := 0; i < max(l.Len(), r.Len()); i++ {
+		elementPath := path + "[" + strconv.Itoa(i) + "]"
+		switch {
+		case i >= l.Len():
+			diffs = append(diffs, &FieldDiff{Path: elementPath, Left: unsetValue, Right: formatValue(fd, r.Get(i))})
+		case i >= r.Len():
+			diffs = append(diffs, &FieldDiff{Path: elementPath, Left: formatValue(fd, l.Get(i)), Right: unsetValue})
+		default:
+			diffs = append(diffs, d.diffValue(elementPath, fd, l.Get(i), r.Get(i))...)
+		}
+	}
+	return diffs
+}
+
+func (d *differ) diffMap(path string, fd protoreflect.FieldDescriptor, l, r protoreflect.Map) []*FieldDiff {
+	var keys []protoreflect.MapKey
+	l.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	r.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		if !l.Has(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	slices.SortFunc(keys, func(a, b protoreflect.MapKey) int {
+		return cmp.Compare(a.String(), b.String())
+	})
+
+	valueField := fd.MapValue()
+	var diffs []*FieldDiff
+	for _, key := range keys {
+		entryPath := path + "[" + key.String() + "]"
+		switch {
+		case !l.Has(key):
+			diffs = append(diffs, &FieldDiff{Path: entryPath, Left: unsetValue, Right: formatValue(valueField, r.Get(key))})
+		case !r.Has(key):
+			diffs = append(diffs, &FieldDiff{Path: entryPath, Left: formatValue(valueField, l.Get(key)), Right: unsetValue})
+		default:
+			diffs = append(diffs, d.diffValue(entryPath, valueField, l.Get(key), r.Get(key))...)
+		}
+	}
+	return diffs
+}
+
+// diffValue compares a single value of fd, which is a message, a scalar, or an element of a list or map field
+func (d *differ) diffValue(path string, fd protoreflect.FieldDescriptor, l, r protoreflect.Value) []*FieldDiff {
+	switch {
+	case fd.Message() != nil && atomicMessages[fd.Message().FullName()]:
+		if proto.Equal(l.Message().Interface(), r.Message().Interface()) {
+			return nil
+		}
+	case fd.Message() != nil:
+		return d.diffMessage(path, l.Message(), r.Message())
+	case equalScalar(fd, l, r):
+		return nil
+	}
+	return []*FieldDiff{{Path: path, Left: formatValue(fd, l), Right: formatValue(fd, r)}}
+}
+
+func equalScalar(fd protoreflect.FieldDescriptor, l, r protoreflect.Value) bool {
+	if fd.Kind() == protoreflect.BytesKind {
+		return bytes.Equal(l.Bytes(), r.Bytes())
+	}
+	return l.Interface() == r.Interface()
+}
+
+func formatField(fd protoreflect.FieldDescriptor, m protoreflect.Message) string {
+	if !m.Has(fd) {
+		return unsetValue
+	}
+	return formatValue(fd, m.Get(fd))
+}
+
+// formatValue formats a value like protojson would, messages are written as compact JSON and JSON payloads are written as
+// their value
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch {
+	case fd.Message() != nil:
+		b, err := temporalproto.CustomJSONMarshalOptions{
+			Metadata: map[string]any{common.EnablePayloadShorthandMetadataKey: true},
+		}.Marshal(v.Message().Interface())
+		if err != nil {
+			return fmt.Sprintf("<%v>", err)
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, b); err != nil {
+			return string(b)
+		}
+		return compact.String()
+	case fd.Kind() == protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case fd.Kind() == protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case fd.Kind() == protoreflect.StringKind:
+		return strconv.Quote(v.String())
+	default:
+		return v.String()
+	}
+}
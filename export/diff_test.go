@@ -0,0 +1,83 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDiffHistoriesOptions(t *testing.T) {
+	left := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	right := proto.Clone(left).(*export.WorkflowExecution)
+	events := right.GetHistory().GetEvents()
+	events[0].GetWorkflowExecutionStartedEventAttributes().Identity = "other-worker"
+	events[1].EventTime = timestamppb.New(events[1].GetEventTime().AsTime().Add(time.Second))
+
+	tests := []struct {
+		name    string
+		options DiffOptions
+		paths   []string
+	}{
+		{
+			name:    "default ignores volatile fields and timestamps",
+			options: DiffOptions{},
+		},
+		{
+			name:    "empty ignored fields compare every field",
+			options: DiffOptions{IgnoredFields: []string{}},
+			paths:   []string{"workflow_execution_started_event_attributes.identity"},
+		},
+		{
+			name:    "compare timestamps",
+			options: DiffOptions{CompareTimestamps: true},
+			paths:   []string{"event_time"},
+		},
+		{
+			name:    "everything",
+			options: DiffOptions{IgnoredFields: []string{}, CompareTimestamps: true},
+			paths:   []string{"workflow_execution_started_event_attributes.identity", "event_time"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			for _, diff := range DiffHistories(left.GetHistory(), right.GetHistory(), tt.options) {
+				if diff.Kind != EventChanged {
+					t.Errorf("event %d is %s, want changed", diff.EventID, diff.Kind)
+				}
+				for _, field := range diff.Fields {
+					paths = append(paths, field.Path)
+				}
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.paths, ",") {
+				t.Errorf("changed fields = %v, want %v", paths, tt.paths)
+			}
+		})
+	}
+}
+
+func TestDiffHistoriesAddedAndRemoved(t *testing.T) {
+	left := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	right := proto.Clone(left).(*export.WorkflowExecution)
+	count := len(left.GetHistory().GetEvents())
+	right.History.Events = right.History.Events[:count-1]
+
+	diffs := DiffHistories(left.GetHistory(), right.GetHistory(), DiffOptions{})
+	if len(diffs) != 1 || diffs[0].Kind != EventRemoved || diffs[0].EventID != int64(count) {
+		t.Fatalf("diffs = %v, want event %d removed", diffs, count)
+	}
+
+	diffs = DiffHistories(right.GetHistory(), left.GetHistory(), DiffOptions{})
+	if len(diffs) != 1 || diffs[0].Kind != EventAdded || diffs[0].EventID != int64(count) {
+		t.Fatalf("diffs = %v, want event %d added", diffs, count)
+	}
+
+	if diffs := DiffHistories(left.GetHistory(), left.GetHistory(), DiffOptions{IgnoredFields: []string{}, CompareTimestamps: true}); len(diffs) != 0 {
+		t.Errorf("identical histories have %d differences", len(diffs))
+	}
+}
@@ -0,0 +1,386 @@
+package export
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+)
+
+// signatureFrames is the number of stack frames of the root cause included in a failure signature
+const signatureFrames = 3
+
+var (
+	uuidPattern   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	hexPattern    = regexp.MustCompile(`\b0x[0-9a-fA-F]+\b|\b[0-9a-fA-F]{16,}\b`)
+	numberPattern = regexp.MustCompile(`\d+`)
+
+	// Stack frame patterns of the Go, Java, Python and TypeScript SDKs
+	goroutineHeader = regexp.MustCompile(`^goroutine \d+ \[.*\]:$`)
+	goFileLine      = regexp.MustCompile(`^\S+\.go:\d+( \+0x[0-9a-f]+)?$`)
+	pythonFrame     = regexp.MustCompile(`^File "([^"]+)", line \d+, in (.+)$`)
+)
+
+type (
+	// ExecutionFailure is the failure chain of an execution that failed, timed out or was terminated
+	ExecutionFailure struct {
+		WorkflowID   string
+		RunID        string
+		WorkflowType string
+		Status       enumspb.WorkflowExecutionStatus
+		CloseTime    time.Time
+		// EventID and EventType identify the event the innermost failure was recorded on. This is the close event of a
+		// failed execution, or the last failed activity, child workflow or workflow task of an execution that timed
+		// out or was terminated
+		EventID   int64
+		EventType enumspb.EventType
+		// Chain is the failure followed by its causes, the last element is the root cause. It is never empty
+		Chain []*FailureCause
+		// Signature identifies the failure independently of IDs, numbers and line numbers, so that executions that
+		// failed for the same reason have the same signature
+		Signature string
+	}
+
+	// FailureCause is a single failure in a failure chain
+	FailureCause struct {
+		// Type is the application error type, or the kind of failure such as ActivityFailure or TimeoutStartToClose
+		Type    string
+		Message string
+		// Subject is the activity type, child workflow type or Nexus operation of a failure that wraps the failure of
+		// one of those
+		Subject string
+		// Frames are the function names of the stack trace, without arguments, offsets, file paths and line numbers
+		Frames []string
+	}
+
+	// FailureGroup holds the executions that failed with the same signature
+	FailureGroup struct {
+		Signature string
+		// Chain is the failure chain of the first execution in the group
+		Chain         []*FailureCause
+		Count         int
+		Statuses      map[enumspb.WorkflowExecutionStatus]int
+		WorkflowTypes map[string]int
+		// Examples are the first executions in the group, up to the limit of the FailureGrouper
+		Examples []*ExecutionFailure
+	}
+
+	// FailureGrouper groups exported workflow executions by failure signature one execution at a time
+	FailureGrouper struct {
+		maxExamples int
+		executions  int
+		groups      map[string]*FailureGroup
+	}
+)
+
+// EventFailure returns the failure recorded on an event, if any, and the ID of the event that scheduled or initiated
+// the failed work
+func EventFailure(event *historypb.HistoryEvent) (*failure.Failure, int64) {
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		return event.GetWorkflowExecutionFailedEventAttributes().GetFailure(), 0
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED:
+		return event.GetWorkflowTaskFailedEventAttributes().GetFailure(), event.GetWorkflowTaskFailedEventAttributes().GetScheduledEventId()
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		a := event.GetActivityTaskFailedEventAttributes()
+		return a.GetFailure(), a.GetScheduledEventId()
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		a := event.GetActivityTaskTimedOutEventAttributes()
+		return a.GetFailure(), a.GetScheduledEventId()
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+		a := event.GetChildWorkflowExecutionFailedEventAttributes()
+		return a.GetFailure(), a.GetInitiatedEventId()
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_COMPLETED:
+		a := event.GetWorkflowExecutionUpdateCompletedEventAttributes()
+		return a.GetOutcome().GetFailure(), a.GetAcceptedEventId()
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_FAILED:
+		a := event.GetNexusOperationFailedEventAttributes()
+		return a.GetFailure(), a.GetScheduledEventId()
+	}
+	return nil, 0
+}
+
+// FailureType classifies a failure by its application error type, or by the kind of failure for other failures
+func FailureType(f *failure.Failure) string {
+	switch {
+	case f.GetApplicationFailureInfo() != nil:
+		if t := f.GetApplicationFailureInfo().GetType(); t != "" {
+			return t
+		}
+		return "ApplicationFailure"
+	case f.GetTimeoutFailureInfo() != nil:
+		return "Timeout" + f.GetTimeoutFailureInfo().GetTimeoutType().String()
+	case f.GetCanceledFailureInfo() != nil:
+		return "Canceled"
+	case f.GetTerminatedFailureInfo() != nil:
+		return "Terminated"
+	case f.GetServerFailureInfo() != nil:
+		return "ServerFailure"
+	case f.GetResetWorkflowFailureInfo() != nil:
+		return "ResetWorkflow"
+	case f.GetActivityFailureInfo() != nil:
+		return "ActivityFailure"
+	case f.GetChildWorkflowExecutionFailureInfo() != nil:
+		return "ChildWorkflowFailure"
+	case f.GetNexusOperationExecutionFailureInfo() != nil:
+		return "NexusOperationFailure"
+	}
+	return "Unknown"
+}
+
+// ExtractFailure returns the failure chain of an execution that failed, timed out or was terminated, or nil for any
+// other execution
+func ExtractFailure(workflow *export.WorkflowExecution) *ExecutionFailure {
+	info, err := GetExportedWorkflowExecutionInfo(workflow)
+	if err != nil {
+		return nil
+	}
+	events := workflow.GetHistory().GetEvents()
+	closeEvent := events[len(events)-1]
+
+	ef := &ExecutionFailure{
+		WorkflowID:   info.WorkflowID,
+		RunID:        info.RunID,
+		WorkflowType: info.WorkflowType,
+		Status:       info.Status,
+		CloseTime:    info.CloseTime,
+		EventID:      closeEvent.GetEventId(),
+		EventType:    closeEvent.GetEventType(),
+	}
+	switch info.Status {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:
+		ef.Chain = failureChain(closeEvent.GetWorkflowExecutionFailedEventAttributes().GetFailure())
+		if len(ef.Chain) == 0 {
+			ef.Chain = []*FailureCause{{Type: "Unknown", Message: "workflow execution failed without a recorded failure"}}
+		}
+	case enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT:
+		ef.Chain = []*FailureCause{{Type: "WorkflowExecutionTimedOut", Message: "workflow execution timed out"}}
+	case enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:
+		ef.Chain = []*FailureCause{{Type: "Terminated", Message: closeEvent.GetWorkflowExecutionTerminatedEventAttributes().GetReason()}}
+	default:
+		return nil
+	}
+
+	// Timeouts and terminations carry no failure of their own, the last work that failed before the execution closed
+	// is the most likely cause
+	if info.Status != enumspb.WORKFLOW_EXECUTION_STATUS_FAILED {
+		if event, cause := lastFailedWork(events); event != nil {
+			ef.EventID, ef.EventType = event.GetEventId(), event.GetEventType()
+			ef.Chain = append(ef.Chain, cause...)
+		}
+	}
+	ef.Signature = failureSignature(ef.Chain)
+	return ef
+}
+
+// ExtractFailures groups the executions that failed, timed out or were terminated by failure signature, ordered by
+// descending count. Every execution in a group is kept as an example
+func ExtractFailures(workflows []*export.WorkflowExecution) []*FailureGroup {
+	grouper := NewFailureGrouper(0)
+	for _, workflow := range workflows {
+		grouper.Add(workflow)
+	}
+	return grouper.Groups()
+}
+
+// NewFailureGrouper returns a FailureGrouper that keeps up to maxExamples executions per group, or every execution if
+// maxExamples is 0
+func NewFailureGrouper(maxExamples int) *FailureGrouper {
+	return &FailureGrouper{maxExamples: maxExamples, groups: map[string]*FailureGroup{}}
+}
+
+// Add extracts the failure of an execution and adds it to its group. It returns the failure, or nil if the execution
+// did not fail
+func (g *FailureGrouper) Add(workflow *export.WorkflowExecution) *ExecutionFailure {
+	g.executions++
+	ef := ExtractFailure(workflow)
+	if ef == nil {
+		return nil
+	}
+
+	group, ok := g.groups[ef.Signature]
+	if !ok {
+		group = &FailureGroup{
+			Signature:     ef.Signature,
+			Chain:         ef.Chain,
+			Statuses:      map[enumspb.WorkflowExecutionStatus]int{},
+			WorkflowTypes: map[string]int{},
+		}
+		g.groups[ef.Signature] = group
+	}
+	group.Count++
+	group.Statuses[ef.Status]++
+	group.WorkflowTypes[ef.WorkflowType]++
+	if g.maxExamples == 0 || len(group.Examples) < g.maxExamples {
+		group.Examples = append(group.Examples, ef)
+	}
+	return ef
+}
+
+// Executions returns the number of executions added, including those that did not fail
+func (g *FailureGrouper) Executions() int {
+	return g.executions
+}
+
+// Groups returns the failure groups ordered by descending count, and by signature for equal counts
+func (g *FailureGrouper) Groups() []*FailureGroup {
+	groups := make([]*FailureGroup, 0, len(g.groups))
+	for _, group := range g.groups {
+		groups = append(groups, group)
+	}
+	slices.SortFunc(groups, func(a, b *FailureGroup) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Signature, b.Signature)
+	})
+	return groups
+}
+
+// lastFailedWork returns the last activity, child workflow, Nexus operation or workflow task that failed, and its
+// failure chain wrapped in a failure naming the work
+func lastFailedWork(events []*historypb.HistoryEvent) (*historypb.HistoryEvent, []*FailureCause) {
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		f, relatedEventID := EventFailure(event)
+		if f == nil || event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_COMPLETED {
+			continue
+		}
+
+		var related *historypb.HistoryEvent
+		if relatedEventID > 0 && relatedEventID <= int64(len(events)) && events[relatedEventID-1].GetEventId() == relatedEventID {
+			related = events[relatedEventID-1]
+		}
+		var wrapper *FailureCause
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED, enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			wrapper = &FailureCause{Type: "ActivityFailure", Subject: related.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()}
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+			wrapper = &FailureCause{Type: "ChildWorkflowFailure", Subject: event.GetChildWorkflowExecutionFailedEventAttributes().GetWorkflowType().GetName()}
+		case enumspb.EVENT_TYPE_NEXUS_OPERATION_FAILED:
+			wrapper = &FailureCause{Type: "NexusOperationFailure", Subject: related.GetNexusOperationScheduledEventAttributes().GetOperation()}
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED:
+			wrapper = &FailureCause{Type: "WorkflowTaskFailure", Message: event.GetWorkflowTaskFailedEventAttributes().GetCause().String()}
+		}
+		return event, append([]*FailureCause{wrapper}, failureChain(f)...)
+	}
+	return nil, nil
+}
+
+// failureChain flattens a failure and its causes
+func failureChain(f *failure.Failure) []*FailureCause {
+	var chain []*FailureCause
+	for ; f != nil; f = f.GetCause() {
+		cause := &FailureCause{
+			Type:    FailureType(f),
+			Message: f.GetMessage(),
+			Frames:  normalizeStackTrace(f.GetStackTrace()),
+		}
+		switch {
+		case f.GetActivityFailureInfo() != nil:
+			cause.Subject = f.GetActivityFailureInfo().GetActivityType().GetName()
+		case f.GetChildWorkflowExecutionFailureInfo() != nil:
+			cause.Subject = f.GetChildWorkflowExecutionFailureInfo().GetWorkflowType().GetName()
+		case f.GetNexusOperationExecutionFailureInfo() != nil:
+			cause.Subject = f.GetNexusOperationExecutionFailureInfo().GetOperation()
+		}
+		chain = append(chain, cause)
+	}
+	return chain
+}
+
+// failureSignature joins the types and subjects of the chain with the normalized message and top stack frames of the
+// root cause. Messages of wrapping failures are left out as they usually repeat the message of their cause
+func failureSignature(chain []*FailureCause) string {
+	parts := make([]string, len(chain))
+	for i, cause := range chain {
+		part := cause.Type
+		if cause.Subject != "" {
+			part += "(" + cause.Subject + ")"
+		}
+		if i == len(chain)-1 {
+			if cause.Message != "" {
+				part += ": " + normalizeMessage(cause.Message)
+			}
+			if len(cause.Frames) > 0 {
+				part += " at " + strings.Join(cause.Frames[:min(len(cause.Frames), signatureFrames)], " < ")
+			}
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, " > ")
+}
+
+// normalizeMessage replaces the values that differ between otherwise identical failures, such as IDs and numbers, with
+// placeholders
+func normalizeMessage(message string) string {
+	message = uuidPattern.ReplaceAllString(message, "<uuid>")
+	message = hexPattern.ReplaceAllString(message, "<hex>")
+	return numberPattern.ReplaceAllString(message, "<n>")
+}
+
+// normalizeStackTrace reduces a stack trace to its function names, which stay the same when code is moved within a
+// file or a different build is deployed. Java and TypeScript frames start with "at", Python frames with "File", and
+// any other trace is read as a Go stack trace of function lines each followed by a file line
+func normalizeStackTrace(stackTrace string) []string {
+	var lines []string
+	for _, line := range strings.Split(stackTrace, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	var frames []string
+	switch {
+	case slices.ContainsFunc(lines, pythonFrame.MatchString):
+		for _, line := range lines {
+			if m := pythonFrame.FindStringSubmatch(line); m != nil {
+				frames = append(frames, m[1]+" in "+m[2])
+			}
+		}
+	case slices.ContainsFunc(lines, isAtFrame):
+		for _, line := range lines {
+			if isAtFrame(line) {
+				frames = append(frames, trimArguments(strings.TrimPrefix(line, "at ")))
+			}
+		}
+	default:
+		for _, line := range lines {
+			if !goroutineHeader.MatchString(line) && !goFileLine.MatchString(line) {
+				frames = append(frames, trimArguments(line))
+			}
+		}
+	}
+	return frames
+}
+
+func isAtFrame(line string) bool {
+	return strings.HasPrefix(line, "at ")
+}
+
+// trimArguments removes the trailing parenthesized arguments or source location of a frame, keeping parentheses that
+// are part of the function name such as Go's "(*Type).Method"
+func trimArguments(frame string) string {
+	if !strings.HasSuffix(frame, ")") {
+		return frame
+	}
+	depth := 0
+	for i := len(frame) - 1; i >= 0; i-- {
+		switch frame[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(frame[:i])
+			}
+		}
+	}
+	return frame
+}
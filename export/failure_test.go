@@ -0,0 +1,113 @@
+package export
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "order 5f0c6e3a-1b2c-4d5e-8f90-a1b2c3d4e5f6 not found", want: "order <uuid> not found"},
+		{message: "bad pointer 0xc000123abc", want: "bad pointer <hex>"},
+		{message: "checksum 9f86d081884c7d659a2feaa0c55ad015 mismatch", want: "checksum <hex> mismatch"},
+		{message: "retry 3 of 10 failed after 250ms", want: "retry <n> of <n> failed after <n>ms"},
+		{message: "connection refused", want: "connection refused"},
+	}
+	for _, tt := range tests {
+		if got := normalizeMessage(tt.message); got != tt.want {
+			t.Errorf("normalizeMessage(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeStackTrace(t *testing.T) {
+	tests := []struct {
+		name       string
+		stackTrace string
+		want       []string
+	}{
+		{
+			name: "go",
+			stackTrace: `goroutine 42 [running]:
+main.(*Activities).Charge(0xc0000a2000, {0x1b2c3d0, 0xc000012345})
+	/app/activities.go:25 +0x65
+go.temporal.io/sdk/internal.(*activityTaskHandlerImpl).Execute(...)
+	/go/pkg/mod/go.temporal.io/sdk@v1.33.0/internal/internal_task_handlers.go:2012 +0x1f`,
+			want: []string{"main.(*Activities).Charge", "go.temporal.io/sdk/internal.(*activityTaskHandlerImpl).Execute"},
+		},
+		{
+			name: "java",
+			stackTrace: `java.lang.IllegalStateException: payment declined
+	at com.example.OrderActivitiesImpl.charge(OrderActivitiesImpl.java:42)
+	at java.base/jdk.internal.reflect.NativeMethodAccessorImpl.invoke0(Native Method)`,
+			want: []string{"com.example.OrderActivitiesImpl.charge", "java.base/jdk.internal.reflect.NativeMethodAccessorImpl.invoke0"},
+		},
+		{
+			name: "python",
+			stackTrace: `Traceback (most recent call last):
+  File "/app/activities.py", line 12, in charge
+    raise ApplicationError("payment declined")
+  File "/app/payments.py", line 40, in submit
+    return client.post(order)`,
+			want: []string{"/app/activities.py in charge", "/app/payments.py in submit"},
+		},
+		{
+			name: "typescript",
+			stackTrace: `ApplicationFailure: payment declined
+    at Activity.charge (/app/lib/activities.js:10:11)
+    at async Activity.execute (/app/node_modules/@temporalio/worker/lib/activity.js:91:34)`,
+			want: []string{"Activity.charge", "async Activity.execute"},
+		},
+		{
+			name:       "empty",
+			stackTrace: "",
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeStackTrace(tt.stackTrace); !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeStackTrace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailureSignature(t *testing.T) {
+	chain := func(message string, frames ...string) []*FailureCause {
+		return []*FailureCause{
+			{Type: "ActivityFailure", Message: "activity error", Subject: "charge"},
+			{Type: "PaymentDeclined", Message: message, Frames: frames},
+		}
+	}
+	signature := failureSignature(chain("order 17 declined", "a", "b", "c", "d"))
+	if want := "ActivityFailure(charge) > PaymentDeclined: order <n> declined at a < b < c"; signature != want {
+		t.Errorf("failureSignature() = %q, want %q", signature, want)
+	}
+	if other := failureSignature(chain("order 42 declined", "a", "b", "c", "e")); other != signature {
+		t.Errorf("failures differing only in numbers and deep frames have different signatures: %q and %q", other, signature)
+	}
+	if other := failureSignature(chain("order 17 declined", "x")); other == signature {
+		t.Errorf("failures with different frames have the same signature %q", other)
+	}
+}
+
+func TestExtractFailureWithoutFailure(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_FAILED)
+	events := workflow.GetHistory().GetEvents()
+	events[len(events)-1].GetWorkflowExecutionFailedEventAttributes().Failure = nil
+
+	ef := ExtractFailure(workflow)
+	if ef == nil || len(ef.Chain) == 0 {
+		t.Fatalf("ExtractFailure() = %+v, want a failure with a labeled chain", ef)
+	}
+	if !strings.HasPrefix(ef.Signature, "Unknown: ") {
+		t.Errorf("signature = %q, want the failure labeled as unknown", ef.Signature)
+	}
+}
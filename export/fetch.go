@@ -0,0 +1,118 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// closedQuery restricts a visibility query to closed executions, which are the only executions an export contains
+const closedQuery = `ExecutionStatus != "Running"`
+
+// FetchOptions configure FetchWorkflows
+type FetchOptions struct {
+	// Query is a visibility list filter such as `WorkflowType = "order" AND CloseTime > "2024-06-01T00:00:00Z"`. Only
+	// closed executions are fetched whatever the query
+	Query string
+	// Limit is the maximum number of executions to fetch, 0 fetches every matching execution
+	Limit int
+	// PageSize is the number of executions listed per visibility request, defaults to 100
+	PageSize int32
+	// Parallelism is the number of histories fetched concurrently, defaults to 4
+	Parallelism int
+}
+
+// FetchWorkflows lists the closed workflow executions of the client's namespace that match the query, fetches their
+// histories and calls fn with each one as an exported workflow execution, in the order the executions were listed. This
+// produces the same executions as the export feature of Temporal Cloud from any namespace, including a local dev server
+func FetchWorkflows(ctx context.Context, c client.Client, options FetchOptions, fn func(workflow *export.WorkflowExecution) error) error {
+	query := closedQuery
+	if options.Query != "" {
+		query = fmt.Sprintf("%s AND (%s)", closedQuery, options.Query)
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	parallelism := options.Parallelism
+	if parallelism <= 0 {
+		parallelism = 4
+	}
+
+	var fetched int
+	var token []byte
+	for {
+		resp, err := c.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			PageSize:      pageSize,
+			NextPageToken: token,
+			Query:         query,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list workflows: %w", err)
+		}
+
+		// Skip running executions even if the visibility store did not apply the status filter of the query
+		var executions []*workflowpb.WorkflowExecutionInfo
+		for _, execution := range resp.GetExecutions() {
+			if execution.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+				executions = append(executions, execution)
+			}
+		}
+		if options.Limit > 0 && fetched+len(executions) > options.Limit {
+			executions = executions[:options.Limit-fetched]
+		}
+
+		histories := make([]*historypb.History, len(executions))
+		errs := make([]error, len(executions))
+		sem := make(chan struct{}, parallelism)
+		var wg sync.WaitGroup
+		for i, execution := range executions {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				histories[i], errs[i] = fetchHistory(ctx, c, execution.GetExecution().GetWorkflowId(), execution.GetExecution().GetRunId())
+			}()
+		}
+		wg.Wait()
+
+		for i, execution := range executions {
+			if errs[i] != nil {
+				return fmt.Errorf("failed to fetch history of workflow %s run %s: %w",
+					execution.GetExecution().GetWorkflowId(), execution.GetExecution().GetRunId(), errs[i])
+			}
+			if err := fn(&export.WorkflowExecution{History: histories[i]}); err != nil {
+				return err
+			}
+		}
+
+		fetched += len(executions)
+		token = resp.GetNextPageToken()
+		if len(token) == 0 || (options.Limit > 0 && fetched >= options.Limit) {
+			return nil
+		}
+	}
+}
+
+func fetchHistory(ctx context.Context, c client.Client, workflowID, runID string) (*historypb.History, error) {
+	iter := c.GetWorkflowHistory(ctx, workflowID, runID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	history := &historypb.History{}
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		history.Events = append(history.Events, event)
+	}
+	return history, nil
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// fakeClient lists executions in pages and serves their histories. Like a visibility store that ignores the status
+// filter, it lists running executions whatever the query
+type fakeClient struct {
+	client.Client
+	executions []*workflowpb.WorkflowExecutionInfo
+	histories  map[string]*historypb.History
+	queries    []string
+}
+
+func (c *fakeClient) ListWorkflow(_ context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	c.queries = append(c.queries, request.GetQuery())
+	start := 0
+	if len(request.GetNextPageToken()) > 0 {
+		start, _ = strconv.Atoi(string(request.GetNextPageToken()))
+	}
+	end := min(start+int(request.GetPageSize()), len(c.executions))
+	resp := &workflowservice.ListWorkflowExecutionsResponse{Executions: c.executions[start:end]}
+	if end < len(c.executions) {
+		resp.NextPageToken = []byte(strconv.Itoa(end))
+	}
+	return resp, nil
+}
+
+func (c *fakeClient) GetWorkflowHistory(_ context.Context, _, runID string, _ bool, _ enumspb.HistoryEventFilterType) client.HistoryEventIterator {
+	return &fakeHistoryIterator{events: c.histories[runID].GetEvents()}
+}
+
+type fakeHistoryIterator struct {
+	events []*historypb.HistoryEvent
+}
+
+func (i *fakeHistoryIterator) HasNext() bool {
+	return len(i.events) > 0
+}
+
+func (i *fakeHistoryIterator) Next() (*historypb.HistoryEvent, error) {
+	event := i.events[0]
+	i.events = i.events[1:]
+	return event, nil
+}
+
+// newFakeClient returns a fakeClient listing workflows, with a running execution listed after every third workflow
+func newFakeClient(t *testing.T, workflows []*export.WorkflowExecution) *fakeClient {
+	t.Helper()
+	c := &fakeClient{histories: map[string]*historypb.History{}}
+	for i, workflow := range workflows {
+		info, err := GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.executions = append(c.executions, &workflowpb.WorkflowExecutionInfo{
+			Execution: &common.WorkflowExecution{WorkflowId: info.WorkflowID, RunId: info.RunID},
+			Status:    info.Status,
+		})
+		c.histories[info.RunID] = workflow.GetHistory()
+		if i%3 == 0 {
+			c.executions = append(c.executions, &workflowpb.WorkflowExecutionInfo{
+				Execution: &common.WorkflowExecution{WorkflowId: "running-" + strconv.Itoa(i), RunId: "running-" + strconv.Itoa(i)},
+				Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			})
+		}
+	}
+	return c
+}
+
+func TestFetchWorkflows(t *testing.T) {
+	workflows := generateWorkflows(t, 10)
+	tests := []struct {
+		name    string
+		options FetchOptions
+		want    int
+		lists   int
+	}{
+		{name: "everything", options: FetchOptions{PageSize: 4}, want: 10, lists: 4},
+		{name: "limit within a page", options: FetchOptions{PageSize: 4, Limit: 2}, want: 2, lists: 1},
+		{name: "limit across pages", options: FetchOptions{PageSize: 4, Limit: 5, Parallelism: 1}, want: 5, lists: 2},
+		{name: "query", options: FetchOptions{Query: `WorkflowType = "order"`}, want: 10, lists: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClient(t, workflows)
+			var fetched []*export.WorkflowExecution
+			err := FetchWorkflows(context.Background(), c, tt.options, func(workflow *export.WorkflowExecution) error {
+				fetched = append(fetched, workflow)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("FetchWorkflows: %v", err)
+			}
+			assertWorkflowsEqual(t, fetched, workflows[:tt.want])
+			if len(c.queries) != tt.lists {
+				t.Errorf("listed %d pages, want %d", len(c.queries), tt.lists)
+			}
+			for _, query := range c.queries {
+				if !strings.HasPrefix(query, closedQuery) || (tt.options.Query != "" && !strings.HasSuffix(query, "("+tt.options.Query+")")) {
+					t.Errorf("query %q does not restrict %q to closed executions", query, tt.options.Query)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchWorkflowsStops(t *testing.T) {
+	c := newFakeClient(t, generateWorkflows(t, 3))
+	var calls int
+	err := FetchWorkflows(context.Background(), c, FetchOptions{}, func(workflow *export.WorkflowExecution) error {
+		calls++
+		return context.Canceled
+	})
+	if err != context.Canceled || calls != 1 {
+		t.Errorf("FetchWorkflows returned %v after %d calls, want the callback error after the first", err, calls)
+	}
+}
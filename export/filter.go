@@ -0,0 +1,179 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+// Filter reports whether an exported workflow execution should be selected
+type Filter func(workflow *export.WorkflowExecution) bool
+
+// MatchAll returns a Filter that selects executions matched by every one of the given filters. With no filters every
+// execution is selected
+func MatchAll(filters ...Filter) Filter {
+	return func(workflow *export.WorkflowExecution) bool {
+		for _, filter := range filters {
+			if !filter(workflow) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ByWorkflowType selects executions whose workflow type is one of the given types
+func ByWorkflowType(types ...string) Filter {
+	return func(workflow *export.WorkflowExecution) bool {
+		startAttributes, err := GetWorkflowStartedEventAttributes(workflow)
+		if err != nil {
+			return false
+		}
+		return slices.Contains(types, startAttributes.GetWorkflowType().GetName())
+	}
+}
+
+// ByWorkflowID selects executions whose workflow ID matches the given regular expression
+func ByWorkflowID(pattern *regexp.Regexp) Filter {
+	return func(workflow *export.WorkflowExecution) bool {
+		startAttributes, err := GetWorkflowStartedEventAttributes(workflow)
+		if err != nil {
+			return false
+		}
+		return pattern.MatchString(startAttributes.GetWorkflowId())
+	}
+}
+
+// ByStatus selects executions whose status, derived from the last event in the history, is one of the given statuses
+func ByStatus(statuses ...enumspb.WorkflowExecutionStatus) Filter {
+	return func(workflow *export.WorkflowExecution) bool {
+		return slices.Contains(statuses, GetWorkflowStatus(workflow))
+	}
+}
+
+// StartedBetween selects executions started within [from, to). A zero from or to leaves that end of the window open
+func StartedBetween(from, to time.Time) Filter {
+	return func(workflow *export.WorkflowExecution) bool {
+		events := workflow.GetHistory().GetEvents()
+		if len(events) == 0 {
+			return false
+		}
+		return inWindow(events[0].GetEventTime().AsTime(), from, to)
+	}
+}
+
+// ClosedBetween selects closed executions whose close event falls within [from, to). A zero from or to leaves that
+// end of the window open. Executions that are still running are never selected
+func ClosedBetween(from, to time.Time) Filter {
+	return func(workflow *export.WorkflowExecution) bool {
+		switch GetWorkflowStatus(workflow) {
+		case enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
+			return false
+		}
+		events := workflow.GetHistory().GetEvents()
+		return inWindow(events[len(events)-1].GetEventTime().AsTime(), from, to)
+	}
+}
+
+// BySearchAttribute selects executions whose final search attribute name, as returned by FinalSearchAttributes,
+// matches value. The value is parsed according to the attribute's type: Keyword attributes must equal the value, Text
+// attributes must contain it, KeywordList attributes must have it as an element, and Int, Double, Bool and Datetime
+// (RFC3339) attributes must equal the parsed value. An empty value selects every execution that has the attribute set
+func BySearchAttribute(name, value string) Filter {
+	return func(workflow *export.WorkflowExecution) bool {
+		payload, ok := FinalSearchAttributes(workflow).GetIndexedFields()[name]
+		if !ok {
+			return false
+		}
+		decoded, err := DecodeSearchAttribute(payload)
+		if err != nil || decoded == nil {
+			return false
+		}
+		if value == "" {
+			return true
+		}
+		return searchAttributeMatches(decoded, SearchAttributeType(payload), value)
+	}
+}
+
+// FilterWorkflows returns the executions in workflows that are selected by filter
+func FilterWorkflows(workflows *export.WorkflowExecutions, filter Filter) []*export.WorkflowExecution {
+	var selected []*export.WorkflowExecution
+	for _, workflow := range workflows.GetItems() {
+		if filter(workflow) {
+			selected = append(selected, workflow)
+		}
+	}
+	return selected
+}
+
+// GlobToRegexp converts a glob pattern where '*' matches any sequence of characters and '?' matches a single
+// character into an anchored regular expression. Unlike path.Match, '*' also matches '/'
+func GlobToRegexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+func searchAttributeMatches(decoded any, attributeType, value string) bool {
+	switch v := decoded.(type) {
+	case string:
+		if attributeType == SearchAttributeText {
+			return strings.Contains(v, value)
+		}
+		return v == value
+	case []string:
+		return slices.Contains(v, value)
+	case int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		return err == nil && v == parsed
+	case float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		return err == nil && v == parsed
+	case bool:
+		parsed, err := strconv.ParseBool(value)
+		return err == nil && v == parsed
+	case time.Time:
+		parsed, err := time.Parse(time.RFC3339, value)
+		return err == nil && v.Equal(parsed)
+	case []int64, []float64, []bool, []time.Time, []any:
+		// Multiple values recorded by older servers match if any one of them does
+		values := reflect.ValueOf(v)
+		for i := range values.Len() {
+			if searchAttributeMatches(values.Index(i).Interface(), attributeType, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == value
+	}
+}
+
+func inWindow(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !t.Before(to) {
+		return false
+	}
+	return true
+}
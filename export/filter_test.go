@@ -0,0 +1,122 @@
+package export
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+func TestFilters(t *testing.T) {
+	workflows := generateWorkflows(t, 100)
+	infos := make(map[*export.WorkflowExecution]*ExecutionInfo, len(workflows))
+	for _, workflow := range workflows {
+		info, err := GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		infos[workflow] = info
+	}
+	first := infos[workflows[0]]
+	from, to := first.StartTime, first.StartTime.Add(30*time.Minute)
+	idGlob, err := GlobToRegexp(first.WorkflowType + "-?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := []enumspb.WorkflowExecutionStatus{enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   func(info *ExecutionInfo) bool
+	}{
+		{
+			name:   "no filters",
+			filter: MatchAll(),
+			want:   func(*ExecutionInfo) bool { return true },
+		},
+		{
+			name:   "workflow type",
+			filter: ByWorkflowType(first.WorkflowType, "unknown"),
+			want:   func(info *ExecutionInfo) bool { return info.WorkflowType == first.WorkflowType },
+		},
+		{
+			name:   "workflow ID glob",
+			filter: ByWorkflowID(idGlob),
+			want: func(info *ExecutionInfo) bool {
+				suffix, ok := strings.CutPrefix(info.WorkflowID, first.WorkflowType+"-")
+				return ok && len(suffix) == 1
+			},
+		},
+		{
+			name:   "status",
+			filter: ByStatus(failed...),
+			want:   func(info *ExecutionInfo) bool { return slices.Contains(failed, info.Status) },
+		},
+		{
+			name:   "started between",
+			filter: StartedBetween(from, to),
+			want:   func(info *ExecutionInfo) bool { return !info.StartTime.Before(from) && info.StartTime.Before(to) },
+		},
+		{
+			name:   "closed after",
+			filter: ClosedBetween(to, time.Time{}),
+			want:   func(info *ExecutionInfo) bool { return !info.CloseTime.Before(to) },
+		},
+		{
+			name:   "all of",
+			filter: MatchAll(ByWorkflowType(first.WorkflowType), ByStatus(first.Status), StartedBetween(time.Time{}, to)),
+			want: func(info *ExecutionInfo) bool {
+				return info.WorkflowType == first.WorkflowType && info.Status == first.Status && info.StartTime.Before(to)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []*export.WorkflowExecution
+			for _, workflow := range workflows {
+				if tt.want(infos[workflow]) {
+					want = append(want, workflow)
+				}
+			}
+			if len(want) == 0 || (len(want) == len(workflows) && tt.name != "no filters") {
+				t.Fatalf("generated workflows do not exercise the filter, %d of %d match", len(want), len(workflows))
+			}
+			got := FilterWorkflows(&export.WorkflowExecutions{Items: workflows}, tt.filter)
+			if !slices.Equal(got, want) {
+				t.Errorf("filter selected %d workflows, want %d", len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob    string
+		matches []string
+		misses  []string
+	}{
+		{glob: "order-*", matches: []string{"order-", "order-1", "order-1/retry"}, misses: []string{"orders-1", "my-order-1"}},
+		{glob: "order-?", matches: []string{"order-1"}, misses: []string{"order-", "order-12"}},
+		{glob: "a.b+(c)", matches: []string{"a.b+(c)"}, misses: []string{"axb+(c)", "a.bb(c)"}},
+	}
+	for _, tt := range tests {
+		pattern, err := GlobToRegexp(tt.glob)
+		if err != nil {
+			t.Fatalf("GlobToRegexp(%q): %v", tt.glob, err)
+		}
+		for _, s := range tt.matches {
+			if !pattern.MatchString(s) {
+				t.Errorf("%q does not match %q", tt.glob, s)
+			}
+		}
+		for _, s := range tt.misses {
+			if pattern.MatchString(s) {
+				t.Errorf("%q matches %q", tt.glob, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,629 @@
+package export
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+const (
+	generatedIdentity    = "exporttool-generate"
+	generatedFailureType = "GeneratedFailure"
+	workflowTaskTimeout  = 10 * time.Second
+)
+
+// generatedStatuses are the statuses a generated execution can close with
+var generatedStatuses = []enumspb.WorkflowExecutionStatus{
+	enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED,
+	enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW,
+	enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT,
+}
+
+type (
+	// GenerateTemplate describes the workflow executions produced by a Generator, usually loaded from a JSON file with
+	// LoadGenerateTemplate
+	GenerateTemplate struct {
+		// StartTime is the beginning of the window in which executions start, defaults to a day before the Generator
+		// is created. Set it to generate the same executions on every run
+		StartTime time.Time `json:"startTime,omitempty"`
+		// Window is the length of the window in which executions start, defaults to a day
+		Window        TemplateDuration    `json:"window,omitempty"`
+		WorkflowTypes []*WorkflowTemplate `json:"workflowTypes"`
+	}
+
+	// WorkflowTemplate describes the executions of a single workflow type. Each execution runs the activities one after
+	// another
+	WorkflowTemplate struct {
+		Name string `json:"name"`
+		// TaskQueue defaults to "generated"
+		TaskQueue string `json:"taskQueue,omitempty"`
+		// Weight is the share of executions of this type relative to the other types, defaults to 1
+		Weight int `json:"weight,omitempty"`
+		// Statuses weigh the statuses executions close with, keyed by status names such as "completed", "failed",
+		// "canceled", "terminated", "continued-as-new" or "timed-out". Every execution completes when unset. Executions
+		// that do not complete stop after a random number of activities, and an execution also fails when one of its
+		// activities runs out of attempts
+		Statuses   map[string]int      `json:"statuses,omitempty"`
+		Activities []*ActivityTemplate `json:"activities,omitempty"`
+	}
+
+	// ActivityTemplate describes an activity run by every execution of a workflow type
+	ActivityTemplate struct {
+		Name string `json:"name"`
+		// MinDuration and MaxDuration bound the run time of each attempt, both default to a second
+		MinDuration TemplateDuration `json:"minDuration,omitempty"`
+		MaxDuration TemplateDuration `json:"maxDuration,omitempty"`
+		// FailureRate is the probability between 0 and 1 that an attempt fails
+		FailureRate float64 `json:"failureRate,omitempty"`
+		// FailureType is the application error type of failed attempts, defaults to "GeneratedFailure"
+		FailureType string `json:"failureType,omitempty"`
+		// MaxAttempts is the maximum number of attempts of the retry policy, defaults to 3
+		MaxAttempts int32 `json:"maxAttempts,omitempty"`
+	}
+
+	// TemplateDuration is a duration written in JSON as a Go duration string such as "1m30s"
+	TemplateDuration time.Duration
+
+	// Generator produces random but valid workflow execution histories from a GenerateTemplate. Generators with the
+	// same template and seed produce the same executions
+	Generator struct {
+		rand      *rand.Rand
+		start     time.Time
+		window    time.Duration
+		types     []*generatedType
+		weight    int
+		count     int
+		continued *continuedRun
+	}
+
+	generatedType struct {
+		template *WorkflowTemplate
+		statuses []enumspb.WorkflowExecutionStatus
+		weights  []int
+		weight   int
+	}
+
+	// continuedRun is the next run of an execution that continued as new, which is generated by the following call to
+	// Generator.Next
+	continuedRun struct {
+		workflowType *generatedType
+		workflowID   string
+		firstRunID   string
+		previousID   string
+		runID        string
+		startTime    time.Time
+	}
+
+	// historyBuilder appends events to a generated history, advancing the clock as it goes
+	historyBuilder struct {
+		rand      *rand.Rand
+		now       time.Time
+		taskID    int64
+		taskQueue string
+		events    []*historypb.HistoryEvent
+	}
+)
+
+// MarshalJSON writes the duration as a Go duration string
+func (d TemplateDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON parses a Go duration string such as "1m30s"
+func (d *TemplateDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"1m30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = TemplateDuration(parsed)
+	return nil
+}
+
+// DefaultGenerateTemplate returns a template modelled on the namespace workflows in this repository, with a mix of
+// successful, failed and retried executions
+func DefaultGenerateTemplate() *GenerateTemplate {
+	return &GenerateTemplate{
+		WorkflowTypes: []*WorkflowTemplate{
+			{
+				Name:      "tmprlcloud-wf.create-namespace",
+				TaskQueue: "cloud-operations",
+				Weight:    1,
+				Statuses:  map[string]int{"completed": 90, "failed": 5, "terminated": 3, "timed-out": 2},
+				Activities: []*ActivityTemplate{
+					{Name: "tmprlcloud-activity.GetNamespace", MinDuration: TemplateDuration(50 * time.Millisecond), MaxDuration: TemplateDuration(500 * time.Millisecond), FailureRate: 0.02},
+					{Name: "tmprlcloud-activity.CreateNamespace", MinDuration: TemplateDuration(time.Second), MaxDuration: TemplateDuration(10 * time.Second), FailureRate: 0.2, FailureType: "temporal-cloud-api-request-failure", MaxAttempts: 5},
+				},
+			},
+			{
+				Name:      "tmprlcloud-wf.reconcile-namespaces",
+				TaskQueue: "cloud-operations",
+				Weight:    2,
+				Statuses:  map[string]int{"completed": 60, "continued-as-new": 35, "canceled": 5},
+				Activities: []*ActivityTemplate{
+					{Name: "tmprlcloud-activity.GetNamespaces", MinDuration: TemplateDuration(100 * time.Millisecond), MaxDuration: TemplateDuration(2 * time.Second), FailureRate: 0.05},
+					{Name: "tmprlcloud-activity.UpdateNamespace", MinDuration: TemplateDuration(500 * time.Millisecond), MaxDuration: TemplateDuration(5 * time.Second), FailureRate: 0.1, FailureType: "temporal-cloud-api-request-failure"},
+				},
+			},
+		},
+	}
+}
+
+// LoadGenerateTemplate reads a generator template from a JSON file
+func LoadGenerateTemplate(path string) (*GenerateTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generator template: %w", err)
+	}
+	var template GenerateTemplate
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse generator template: %w", err)
+	}
+	return &template, nil
+}
+
+// NewGenerator returns a Generator for the template seeded with seed, or an error if the template is invalid
+func NewGenerator(template *GenerateTemplate, seed uint64) (*Generator, error) {
+	if len(template.WorkflowTypes) == 0 {
+		return nil, fmt.Errorf("generator template has no workflow types")
+	}
+	if template.Window < 0 {
+		return nil, fmt.Errorf("generator template window must not be negative")
+	}
+
+	g := &Generator{
+		rand:   rand.New(rand.NewPCG(seed, seed)),
+		start:  template.StartTime,
+		window: time.Duration(cmp.Or(template.Window, TemplateDuration(24*time.Hour))),
+	}
+	if g.start.IsZero() {
+		g.start = time.Now().UTC().Add(-24 * time.Hour).Truncate(time.Second)
+	}
+	for _, wt := range template.WorkflowTypes {
+		if wt.Name == "" {
+			return nil, fmt.Errorf("generator template workflow type has no name")
+		}
+		if wt.Weight < 0 {
+			return nil, fmt.Errorf("workflow type %s: weight must not be negative", wt.Name)
+		}
+		for _, a := range wt.Activities {
+			if a.Name == "" {
+				return nil, fmt.Errorf("workflow type %s: activity has no name", wt.Name)
+			}
+			if a.MinDuration < 0 || (a.MaxDuration != 0 && a.MaxDuration < a.MinDuration) {
+				return nil, fmt.Errorf("workflow type %s: activity %s has an invalid duration range", wt.Name, a.Name)
+			}
+			if a.FailureRate < 0 || a.FailureRate > 1 {
+				return nil, fmt.Errorf("workflow type %s: activity %s failure rate must be between 0 and 1", wt.Name, a.Name)
+			}
+			if a.MaxAttempts < 0 {
+				return nil, fmt.Errorf("workflow type %s: activity %s max attempts must not be negative", wt.Name, a.Name)
+			}
+		}
+
+		t := &generatedType{template: wt, weight: cmp.Or(wt.Weight, 1)}
+		weights := map[enumspb.WorkflowExecutionStatus]int{}
+		for name, weight := range wt.Statuses {
+			status, err := ParseWorkflowStatus(name)
+			if err != nil {
+				return nil, fmt.Errorf("workflow type %s: %w", wt.Name, err)
+			}
+			if !slices.Contains(generatedStatuses, status) {
+				return nil, fmt.Errorf("workflow type %s: executions cannot be generated with status %s", wt.Name, status)
+			}
+			if weight < 0 {
+				return nil, fmt.Errorf("workflow type %s: weight of status %s must not be negative", wt.Name, status)
+			}
+			weights[status] += weight
+		}
+		// Map iteration order is random, keep the statuses in a fixed order so that a seed always produces the same
+		// executions
+		for _, status := range generatedStatuses {
+			if weight, ok := weights[status]; ok {
+				t.statuses = append(t.statuses, status)
+				t.weights = append(t.weights, weight)
+			}
+		}
+		g.types = append(g.types, t)
+		g.weight += t.weight
+	}
+	return g, nil
+}
+
+// Next returns the next generated workflow execution, or an error if one of its payloads cannot be encoded. The run
+// following an execution that continued as new is returned by the next call
+func (g *Generator) Next() (*export.WorkflowExecution, error) {
+	g.count++
+	run := g.continued
+	g.continued = nil
+	if run == nil {
+		t := g.pickType()
+		start := g.start.Add(time.Duration(g.rand.Int64N(int64(g.window) + 1)))
+		runID := g.uuid()
+		run = &continuedRun{
+			workflowType: t,
+			workflowID:   t.template.Name + "-" + strconv.Itoa(g.count),
+			firstRunID:   runID,
+			runID:        runID,
+			startTime:    start,
+		}
+	}
+	return g.generate(run)
+}
+
+func (g *Generator) pickType() *generatedType {
+	n := g.rand.IntN(g.weight)
+	for _, t := range g.types {
+		if n < t.weight {
+			return t
+		}
+		n -= t.weight
+	}
+	return g.types[len(g.types)-1]
+}
+
+func (t *generatedType) pickStatus(r *rand.Rand) enumspb.WorkflowExecutionStatus {
+	total := 0
+	for _, weight := range t.weights {
+		total += weight
+	}
+	if total == 0 {
+		return enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+	}
+	n := r.IntN(total)
+	for i, weight := range t.weights {
+		if n < weight {
+			return t.statuses[i]
+		}
+		n -= weight
+	}
+	return enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+}
+
+// uuid returns a random version 4 UUID drawn from the generator's source, so that run IDs are reproducible
+func (g *Generator) uuid() string {
+	hi, lo := g.rand.Uint64(), g.rand.Uint64()
+	hi = hi&^(0xf<<12) | 0x4<<12
+	lo = lo&^(0x3<<62) | 0x2<<62
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", hi>>32, hi>>16&0xffff, hi&0xffff, lo>>48, lo&0xffffffffffff)
+}
+
+func (g *Generator) generate(run *continuedRun) (*export.WorkflowExecution, error) {
+	wt := run.workflowType.template
+	b := &historyBuilder{
+		rand:      g.rand,
+		now:       run.startTime,
+		taskID:    1 << 20,
+		taskQueue: cmp.Or(wt.TaskQueue, "generated"),
+	}
+	input, err := payloads(map[string]any{"workflowId": run.workflowID, "sequence": g.count})
+	if err != nil {
+		return nil, err
+	}
+
+	started := &historypb.WorkflowExecutionStartedEventAttributes{
+		WorkflowType:            &common.WorkflowType{Name: wt.Name},
+		TaskQueue:               b.taskQueueProto(),
+		Input:                   input,
+		WorkflowTaskTimeout:     durationpb.New(workflowTaskTimeout),
+		ContinuedExecutionRunId: run.previousID,
+		OriginalExecutionRunId:  run.runID,
+		Identity:                generatedIdentity,
+		FirstExecutionRunId:     run.firstRunID,
+		Attempt:                 1,
+		WorkflowId:              run.workflowID,
+	}
+	if run.previousID != "" {
+		started.Initiator = enumspb.CONTINUE_AS_NEW_INITIATOR_WORKFLOW
+	}
+	b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED).Attributes = &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+		WorkflowExecutionStartedEventAttributes: started,
+	}
+	completedEventID := b.workflowTask()
+
+	// Executions that do not complete stop after a random number of activities
+	status := run.workflowType.pickStatus(g.rand)
+	activities := wt.Activities
+	if status != enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED && status != enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW {
+		activities = activities[:g.rand.IntN(len(activities)+1)]
+	}
+	var workflowFailure *failure.Failure
+	for i, activity := range activities {
+		if workflowFailure, err = b.activity(activity, strconv.Itoa(i+1), completedEventID); err != nil {
+			return nil, err
+		}
+		completedEventID = b.workflowTask()
+		if workflowFailure != nil {
+			status = enumspb.WORKFLOW_EXECUTION_STATUS_FAILED
+			break
+		}
+	}
+
+	switch status {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		result, err := payloads("done")
+		if err != nil {
+			return nil, err
+		}
+		b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED).Attributes = &historypb.HistoryEvent_WorkflowExecutionCompletedEventAttributes{
+			WorkflowExecutionCompletedEventAttributes: &historypb.WorkflowExecutionCompletedEventAttributes{
+				Result:                       result,
+				WorkflowTaskCompletedEventId: completedEventID,
+			},
+		}
+	case enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:
+		if workflowFailure == nil {
+			workflowFailure = applicationFailure("workflow failed", generatedFailureType)
+		}
+		b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED).Attributes = &historypb.HistoryEvent_WorkflowExecutionFailedEventAttributes{
+			WorkflowExecutionFailedEventAttributes: &historypb.WorkflowExecutionFailedEventAttributes{
+				Failure:                      workflowFailure,
+				RetryState:                   enumspb.RETRY_STATE_RETRY_POLICY_NOT_SET,
+				WorkflowTaskCompletedEventId: completedEventID,
+			},
+		}
+	case enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED:
+		b.advance(time.Second, 10*time.Second)
+		b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCEL_REQUESTED).Attributes = &historypb.HistoryEvent_WorkflowExecutionCancelRequestedEventAttributes{
+			WorkflowExecutionCancelRequestedEventAttributes: &historypb.WorkflowExecutionCancelRequestedEventAttributes{
+				Cause:    "canceled by " + generatedIdentity,
+				Identity: generatedIdentity,
+			},
+		}
+		completedEventID = b.workflowTask()
+		b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED).Attributes = &historypb.HistoryEvent_WorkflowExecutionCanceledEventAttributes{
+			WorkflowExecutionCanceledEventAttributes: &historypb.WorkflowExecutionCanceledEventAttributes{
+				WorkflowTaskCompletedEventId: completedEventID,
+			},
+		}
+	case enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:
+		b.advance(time.Second, time.Minute)
+		b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED).Attributes = &historypb.HistoryEvent_WorkflowExecutionTerminatedEventAttributes{
+			WorkflowExecutionTerminatedEventAttributes: &historypb.WorkflowExecutionTerminatedEventAttributes{
+				Reason:   "terminated by " + generatedIdentity,
+				Identity: generatedIdentity,
+			},
+		}
+	case enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT:
+		b.advance(time.Minute, time.Hour)
+		// The run timeout is only known once the history is built
+		started.WorkflowRunTimeout = durationpb.New(b.now.Sub(run.startTime))
+		b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT).Attributes = &historypb.HistoryEvent_WorkflowExecutionTimedOutEventAttributes{
+			WorkflowExecutionTimedOutEventAttributes: &historypb.WorkflowExecutionTimedOutEventAttributes{
+				RetryState: enumspb.RETRY_STATE_TIMEOUT,
+			},
+		}
+	case enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW:
+		next := &continuedRun{
+			workflowType: run.workflowType,
+			workflowID:   run.workflowID,
+			firstRunID:   run.firstRunID,
+			previousID:   run.runID,
+			runID:        g.uuid(),
+		}
+		b.add(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW).Attributes = &historypb.HistoryEvent_WorkflowExecutionContinuedAsNewEventAttributes{
+			WorkflowExecutionContinuedAsNewEventAttributes: &historypb.WorkflowExecutionContinuedAsNewEventAttributes{
+				NewExecutionRunId:            next.runID,
+				WorkflowType:                 started.GetWorkflowType(),
+				TaskQueue:                    started.GetTaskQueue(),
+				Input:                        input,
+				WorkflowTaskTimeout:          started.GetWorkflowTaskTimeout(),
+				WorkflowTaskCompletedEventId: completedEventID,
+				Initiator:                    enumspb.CONTINUE_AS_NEW_INITIATOR_WORKFLOW,
+			},
+		}
+		b.advance(10*time.Millisecond, 100*time.Millisecond)
+		next.startTime = b.now
+		g.continued = next
+	}
+
+	return &export.WorkflowExecution{History: &historypb.History{Events: b.events}}, nil
+}
+
+// add appends an event of the given type at the current time, the caller sets its attributes
+func (b *historyBuilder) add(eventType enumspb.EventType) *historypb.HistoryEvent {
+	b.taskID++
+	event := &historypb.HistoryEvent{
+		EventId:   int64(len(b.events) + 1),
+		EventTime: timestamppb.New(b.now),
+		EventType: eventType,
+		TaskId:    b.taskID,
+	}
+	b.events = append(b.events, event)
+	return event
+}
+
+// advance moves the clock forward by a random duration in [min, max]
+func (b *historyBuilder) advance(min, max time.Duration) {
+	b.now = b.now.Add(b.duration(min, max))
+}
+
+func (b *historyBuilder) duration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(b.rand.Int64N(int64(max-min)+1))
+}
+
+func (b *historyBuilder) taskQueueProto() *taskqueue.TaskQueue {
+	return &taskqueue.TaskQueue{Name: b.taskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL}
+}
+
+// workflowTask appends a scheduled, started and completed workflow task and returns the ID of the completed event
+func (b *historyBuilder) workflowTask() int64 {
+	b.advance(time.Millisecond, 20*time.Millisecond)
+	scheduled := b.add(enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED)
+	scheduled.Attributes = &historypb.HistoryEvent_WorkflowTaskScheduledEventAttributes{
+		WorkflowTaskScheduledEventAttributes: &historypb.WorkflowTaskScheduledEventAttributes{
+			TaskQueue:           b.taskQueueProto(),
+			StartToCloseTimeout: durationpb.New(workflowTaskTimeout),
+			Attempt:             1,
+		},
+	}
+	b.advance(time.Millisecond, 50*time.Millisecond)
+	started := b.add(enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED)
+	started.Attributes = &historypb.HistoryEvent_WorkflowTaskStartedEventAttributes{
+		WorkflowTaskStartedEventAttributes: &historypb.WorkflowTaskStartedEventAttributes{
+			ScheduledEventId: scheduled.GetEventId(),
+			Identity:         generatedIdentity,
+		},
+	}
+	b.advance(time.Millisecond, 50*time.Millisecond)
+	completed := b.add(enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED)
+	completed.Attributes = &historypb.HistoryEvent_WorkflowTaskCompletedEventAttributes{
+		WorkflowTaskCompletedEventAttributes: &historypb.WorkflowTaskCompletedEventAttributes{
+			ScheduledEventId: scheduled.GetEventId(),
+			StartedEventId:   started.GetEventId(),
+			Identity:         generatedIdentity,
+		},
+	}
+	return completed.GetEventId()
+}
+
+// activity appends the events of an activity that is retried until an attempt succeeds or it runs out of attempts. Like
+// the server, only the final attempt is recorded. It returns the failure to fail the workflow with if every attempt
+// failed
+func (b *historyBuilder) activity(template *ActivityTemplate, activityID string, completedEventID int64) (*failure.Failure, error) {
+	maxAttempts := cmp.Or(template.MaxAttempts, 3)
+	minDuration := time.Duration(cmp.Or(template.MinDuration, TemplateDuration(time.Second)))
+	maxDuration := max(time.Duration(template.MaxDuration), minDuration)
+	failureType := cmp.Or(template.FailureType, generatedFailureType)
+
+	input, err := payloads(map[string]any{"activityId": activityID})
+	if err != nil {
+		return nil, err
+	}
+	scheduled := b.add(enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED)
+	scheduled.Attributes = &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+		ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+			ActivityId:                   activityID,
+			ActivityType:                 &common.ActivityType{Name: template.Name},
+			TaskQueue:                    b.taskQueueProto(),
+			Input:                        input,
+			StartToCloseTimeout:          durationpb.New(max(2*maxDuration, time.Minute)),
+			WorkflowTaskCompletedEventId: completedEventID,
+			RetryPolicy: &common.RetryPolicy{
+				InitialInterval:    durationpb.New(time.Second),
+				BackoffCoefficient: 2,
+				MaximumInterval:    durationpb.New(100 * time.Second),
+				MaximumAttempts:    maxAttempts,
+			},
+		},
+	}
+
+	// Earlier attempts only show up as time passing and as the last failure of the final attempt
+	var attempt int32
+	var lastFailure *failure.Failure
+	var failed bool
+	for attempt = 1; ; attempt++ {
+		b.advance(5*time.Millisecond, 100*time.Millisecond)
+		failed = b.rand.Float64() < template.FailureRate
+		if !failed || attempt == maxAttempts {
+			break
+		}
+		b.advance(minDuration, maxDuration)
+		b.now = b.now.Add(retryBackoff(attempt))
+		lastFailure = applicationFailure(fmt.Sprintf("attempt %d of %s failed", attempt, template.Name), failureType)
+	}
+
+	started := b.add(enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED)
+	started.Attributes = &historypb.HistoryEvent_ActivityTaskStartedEventAttributes{
+		ActivityTaskStartedEventAttributes: &historypb.ActivityTaskStartedEventAttributes{
+			ScheduledEventId: scheduled.GetEventId(),
+			Identity:         generatedIdentity,
+			Attempt:          attempt,
+			LastFailure:      lastFailure,
+		},
+	}
+	b.advance(minDuration, maxDuration)
+
+	if !failed {
+		result, err := payloads(map[string]any{"activityId": activityID, "attempt": attempt})
+		if err != nil {
+			return nil, err
+		}
+		b.add(enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED).Attributes = &historypb.HistoryEvent_ActivityTaskCompletedEventAttributes{
+			ActivityTaskCompletedEventAttributes: &historypb.ActivityTaskCompletedEventAttributes{
+				Result:           result,
+				ScheduledEventId: scheduled.GetEventId(),
+				StartedEventId:   started.GetEventId(),
+				Identity:         generatedIdentity,
+			},
+		}
+		return nil, nil
+	}
+
+	activityFailure := applicationFailure(fmt.Sprintf("attempt %d of %s failed", attempt, template.Name), failureType)
+	b.add(enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED).Attributes = &historypb.HistoryEvent_ActivityTaskFailedEventAttributes{
+		ActivityTaskFailedEventAttributes: &historypb.ActivityTaskFailedEventAttributes{
+			Failure:          activityFailure,
+			ScheduledEventId: scheduled.GetEventId(),
+			StartedEventId:   started.GetEventId(),
+			Identity:         generatedIdentity,
+			RetryState:       enumspb.RETRY_STATE_MAXIMUM_ATTEMPTS_REACHED,
+		},
+	}
+	return &failure.Failure{
+		Message: "activity error",
+		Source:  "GoSDK",
+		Cause:   activityFailure,
+		FailureInfo: &failure.Failure_ActivityFailureInfo{ActivityFailureInfo: &failure.ActivityFailureInfo{
+			ScheduledEventId: scheduled.GetEventId(),
+			StartedEventId:   started.GetEventId(),
+			Identity:         generatedIdentity,
+			ActivityType:     &common.ActivityType{Name: template.Name},
+			ActivityId:       activityID,
+			RetryState:       enumspb.RETRY_STATE_MAXIMUM_ATTEMPTS_REACHED,
+		}},
+	}, nil
+}
+
+func applicationFailure(message, failureType string) *failure.Failure {
+	return &failure.Failure{
+		Message: message,
+		Source:  "GoSDK",
+		FailureInfo: &failure.Failure_ApplicationFailureInfo{ApplicationFailureInfo: &failure.ApplicationFailureInfo{
+			Type: failureType,
+		}},
+	}
+}
+
+// payloads encodes a value with the SDK's default data converter, as a worker would
+func payloads(value any) (*common.Payloads, error) {
+	p, err := converter.GetDefaultDataConverter().ToPayloads(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode generated payload: %w", err)
+	}
+	return p, nil
+}
+
+// retryBackoff returns the delay after a failed attempt, doubling from a second up to the retry policy's maximum
+// interval of 100 seconds. The shift is capped so that large attempt numbers cannot overflow
+func retryBackoff(attempt int32) time.Duration {
+	if attempt > 8 {
+		return 100 * time.Second
+	}
+	return min(time.Second<<(attempt-1), 100*time.Second)
+}
@@ -0,0 +1,117 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+func TestGeneratorProducesValidExports(t *testing.T) {
+	workflows := generateWorkflows(t, 200)
+	verifier := NewVerifier()
+	statuses := map[enumspb.WorkflowExecutionStatus]int{}
+	for i, workflow := range workflows {
+		for _, problem := range verifier.Verify("generated", i+1, workflow) {
+			t.Errorf("generated workflow has a problem: %s", problem)
+		}
+		statuses[GetWorkflowStatus(workflow)]++
+	}
+	for _, status := range generatedStatuses {
+		if statuses[status] == 0 {
+			t.Errorf("no generated workflow has status %s", status)
+		}
+	}
+}
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	serialize := func() []byte {
+		data, err := SerializeExportedWorkflows(&export.WorkflowExecutions{Items: generateWorkflows(t, 50)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	if !bytes.Equal(serialize(), serialize()) {
+		t.Error("the same seed generated different exports")
+	}
+}
+
+func TestGeneratorTemplate(t *testing.T) {
+	template := &GenerateTemplate{
+		StartTime: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Window:    TemplateDuration(time.Hour),
+		WorkflowTypes: []*WorkflowTemplate{{
+			Name:       "order",
+			TaskQueue:  "orders",
+			Statuses:   map[string]int{"failed": 1},
+			Activities: []*ActivityTemplate{{Name: "charge", FailureRate: 1, MaxAttempts: 2}},
+		}},
+	}
+	generator, err := NewGenerator(template, 1)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	for range 10 {
+		workflow, err := generator.Next()
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		info, err := GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.WorkflowType != "order" || info.TaskQueue != "orders" || info.Status != enumspb.WORKFLOW_EXECUTION_STATUS_FAILED {
+			t.Errorf("generated %s on %s with status %s, want a failed order on orders", info.WorkflowType, info.TaskQueue, info.Status)
+		}
+		if info.StartTime.Before(template.StartTime) || info.StartTime.After(template.StartTime.Add(time.Hour)) {
+			t.Errorf("start time %s is outside the template window", info.StartTime)
+		}
+		for _, run := range ActivityRuns(workflow) {
+			if run.Status != ActivityFailed || run.Attempt != 2 {
+				t.Errorf("activity %s is %s after %d attempts, want failed after 2", run.ActivityID, run.Status, run.Attempt)
+			}
+		}
+	}
+
+	invalid := []*GenerateTemplate{
+		{},
+		{WorkflowTypes: []*WorkflowTemplate{{}}},
+		{WorkflowTypes: []*WorkflowTemplate{{Name: "order", Statuses: map[string]int{"running": 1}}}},
+		{WorkflowTypes: []*WorkflowTemplate{{Name: "order", Activities: []*ActivityTemplate{{Name: "charge", FailureRate: 2}}}}},
+	}
+	for i, template := range invalid {
+		if _, err := NewGenerator(template, 1); err == nil {
+			t.Errorf("invalid template %d was accepted", i)
+		}
+	}
+}
+
+func TestGeneratorManyAttempts(t *testing.T) {
+	template := &GenerateTemplate{
+		StartTime: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		WorkflowTypes: []*WorkflowTemplate{{
+			Name:       "order",
+			Activities: []*ActivityTemplate{{Name: "charge", FailureRate: 1, MaxAttempts: 100}},
+		}},
+	}
+	generator, err := NewGenerator(template, 1)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	workflow, err := generator.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	for _, problem := range VerifyWorkflow(workflow) {
+		t.Errorf("generated workflow has a problem: %s", problem)
+	}
+
+	for attempt, want := range map[int32]time.Duration{1: time.Second, 2: 2 * time.Second, 7: 64 * time.Second, 8: 100 * time.Second, 64: 100 * time.Second, 100: 100 * time.Second} {
+		if got := retryBackoff(attempt); got != want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
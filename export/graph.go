@@ -0,0 +1,198 @@
+package export
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+type (
+	// ExecutionNode is a workflow execution in an ExecutionGraph
+	ExecutionNode struct {
+		WorkflowID string
+		RunID      string
+		// Info is nil for executions that are referenced by other executions but were not added to the graph, for
+		// example children whose histories are in an export that was not loaded
+		Info *ExecutionInfo
+		// Parent is the execution that started this execution as a child workflow. Only the first run of a child
+		// workflow's continue-as-new chain is linked to the parent
+		Parent   *ExecutionNode
+		Children []*ExecutionNode
+		// Previous and Next link the runs of a chain of continue-as-new executions and retries
+		Previous *ExecutionNode
+		Next     *ExecutionNode
+		// ResetFrom is the run this execution was reset from, and Resets are the runs created by resetting this
+		// execution. A reset run is linked to its base run only, not to the parent or previous run it copied
+		ResetFrom *ExecutionNode
+		Resets    []*ExecutionNode
+	}
+
+	// ExecutionGraph links exported workflow executions to their parents, children, and the other runs of their
+	// continue-as-new chains, across any number of export files
+	ExecutionGraph struct {
+		nodes map[string]*ExecutionNode
+		order []*ExecutionNode
+	}
+)
+
+// NewExecutionGraph returns an empty ExecutionGraph
+func NewExecutionGraph() *ExecutionGraph {
+	return &ExecutionGraph{nodes: map[string]*ExecutionNode{}}
+}
+
+// Add adds a workflow execution to the graph and links it to the executions it references. Executions may be added in
+// any order
+func (g *ExecutionGraph) Add(workflow *export.WorkflowExecution) error {
+	info, err := GetExportedWorkflowExecutionInfo(workflow)
+	if err != nil {
+		return err
+	}
+
+	node := g.node(info.WorkflowID, info.RunID)
+	if node.Info != nil {
+		return nil
+	}
+	node.Info = info
+
+	// A reset run copies the history of its base run up to the reset point, which ends with a workflow task failed
+	// with the reset cause. Only the events after it belong to the reset run
+	events := workflow.GetHistory().GetEvents()
+	if info.RunID != info.OriginalRunID {
+		for i, event := range events {
+			attributes := event.GetWorkflowTaskFailedEventAttributes()
+			if attributes.GetCause() == enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW && attributes.GetNewRunId() == info.RunID {
+				addReset(g.node(info.WorkflowID, cmp.Or(attributes.GetBaseRunId(), info.OriginalRunID)), node)
+				events = events[i+1:]
+				break
+			}
+		}
+	}
+
+	if node.ResetFrom == nil {
+		if info.ContinuedFromRunID != "" {
+			link(g.node(info.WorkflowID, info.ContinuedFromRunID), node)
+		} else if info.ParentExecution != nil {
+			addChild(g.node(info.ParentExecution.GetWorkflowId(), info.ParentExecution.GetRunId()), node)
+		}
+	}
+	if info.NewRunID != "" {
+		link(node, g.node(info.WorkflowID, info.NewRunID))
+	}
+
+	for _, event := range events {
+		if event.GetEventType() == enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED {
+			child := event.GetChildWorkflowExecutionStartedEventAttributes().GetWorkflowExecution()
+			addChild(node, g.node(child.GetWorkflowId(), child.GetRunId()))
+		}
+	}
+	return nil
+}
+
+// Node returns the execution with the given run ID, or nil if the graph has no such execution
+func (g *ExecutionGraph) Node(runID string) *ExecutionNode {
+	return g.nodes[runID]
+}
+
+// Roots returns the executions that are neither a child workflow, a continuation nor a reset of another execution, in
+// the order they were first seen
+func (g *ExecutionGraph) Roots() []*ExecutionNode {
+	var roots []*ExecutionNode
+	for _, node := range g.order {
+		if node.Parent == nil && node.Previous == nil && node.ResetFrom == nil {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+func (g *ExecutionGraph) node(workflowID, runID string) *ExecutionNode {
+	node, ok := g.nodes[runID]
+	if !ok {
+		node = &ExecutionNode{WorkflowID: workflowID, RunID: runID}
+		g.nodes[runID] = node
+		g.order = append(g.order, node)
+	}
+	return node
+}
+
+func link(previous, next *ExecutionNode) {
+	if previous == next || previous.Next != nil || next.Previous != nil {
+		return
+	}
+	previous.Next, next.Previous = next, previous
+}
+
+func addReset(base, reset *ExecutionNode) {
+	if base == reset || reset.ResetFrom != nil {
+		return
+	}
+	reset.ResetFrom = base
+	base.Resets = append(base.Resets, reset)
+}
+
+func addChild(parent, child *ExecutionNode) {
+	if parent == child || child.Parent != nil {
+		return
+	}
+	child.Parent = parent
+	parent.Children = append(parent.Children, child)
+}
+
+// FormatExecutionTree renders the executions below roots as an indented tree. The runs of a continue-as-new chain are
+// listed one after another at the same depth, each followed by the runs reset from it and the child workflows it
+// started
+func FormatExecutionTree(roots []*ExecutionNode) string {
+	var sb strings.Builder
+	visited := map[*ExecutionNode]bool{}
+	for _, root := range roots {
+		formatChain(&sb, root, "", "", visited)
+	}
+	return sb.String()
+}
+
+// formatChain writes a chain of runs starting at node. The first run is prefixed with first, the following runs and all
+// children are prefixed with rest
+func formatChain(sb *strings.Builder, node *ExecutionNode, first, rest string, visited map[*ExecutionNode]bool) {
+	prefix := first
+	for ; node != nil && !visited[node]; node = node.Next {
+		visited[node] = true
+		sb.WriteString(prefix)
+		if node.Previous != nil {
+			sb.WriteString("↳ ")
+		}
+		if node.ResetFrom != nil {
+			sb.WriteString("↺ ")
+		}
+		sb.WriteString(describeNode(node))
+		sb.WriteString("\n")
+		prefix = rest
+
+		// Resets and children are drawn below their run, connected to the chain's branch
+		childPrefix := rest
+		if node.Next != nil {
+			childPrefix += "│ "
+		} else {
+			childPrefix += "  "
+		}
+		branches := append(slices.Clip(node.Resets), node.Children...)
+		for i, child := range branches {
+			if i == len(branches)-1 {
+				formatChain(sb, child, childPrefix+"└─ ", childPrefix+"   ", visited)
+			} else {
+				formatChain(sb, child, childPrefix+"├─ ", childPrefix+"│  ", visited)
+			}
+		}
+	}
+}
+
+func describeNode(node *ExecutionNode) string {
+	if node.Info == nil {
+		return fmt.Sprintf("WorkflowID: %s, RunID: %s (not in export)", node.WorkflowID, node.RunID)
+	}
+	return fmt.Sprintf("WorkflowID: %s, RunID: %s, WorkflowType: %s, Status: %s, Started: %s", node.WorkflowID, node.RunID,
+		node.Info.WorkflowType, node.Info.Status, node.Info.StartTime.UTC().Format("2006-01-02T15:04:05Z"))
+}
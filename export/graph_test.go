@@ -0,0 +1,81 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+func TestExecutionGraphReset(t *testing.T) {
+	base := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	reset := resetWorkflow(t, base, "reset-run")
+	baseRunID := base.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes().GetOriginalExecutionRunId()
+
+	orders := map[string][]*export.WorkflowExecution{
+		"base first":  {base, reset},
+		"reset first": {reset, base},
+	}
+	for name, workflows := range orders {
+		t.Run(name, func(t *testing.T) {
+			graph := NewExecutionGraph()
+			for _, workflow := range workflows {
+				if err := graph.Add(workflow); err != nil {
+					t.Fatalf("Add: %v", err)
+				}
+			}
+
+			baseNode, resetNode := graph.Node(baseRunID), graph.Node("reset-run")
+			if baseNode == nil || baseNode.Info == nil || baseNode.RunID != baseRunID {
+				t.Fatalf("base run missing from graph: %+v", baseNode)
+			}
+			if resetNode == nil || resetNode.Info == nil || resetNode.RunID != "reset-run" {
+				t.Fatalf("reset run missing from graph: %+v", resetNode)
+			}
+			if resetNode.ResetFrom != baseNode || len(baseNode.Resets) != 1 || baseNode.Resets[0] != resetNode {
+				t.Errorf("reset run is not linked to its base run")
+			}
+			if len(graph.order) != 2 {
+				t.Errorf("graph has %d nodes, want 2", len(graph.order))
+			}
+			if roots := graph.Roots(); len(roots) != 1 || roots[0] != baseNode {
+				t.Errorf("Roots() = %v, want only the base run", roots)
+			}
+
+			tree := FormatExecutionTree(graph.Roots())
+			if !strings.Contains(tree, "└─ ↺ WorkflowID: "+baseNode.WorkflowID+", RunID: reset-run") {
+				t.Errorf("tree does not show the reset run below its base run:\n%s", tree)
+			}
+		})
+	}
+}
+
+func TestExecutionGraphContinueAsNew(t *testing.T) {
+	workflows := generateWorkflows(t, 50)
+	graph := NewExecutionGraph()
+	for _, workflow := range workflows {
+		if err := graph.Add(workflow); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	var chains int
+	for _, workflow := range workflows {
+		info, err := GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Status != enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW {
+			continue
+		}
+		chains++
+		node := graph.Node(info.RunID)
+		if node.Next == nil || node.Next.RunID != info.NewRunID || node.Next.Previous != node {
+			t.Errorf("run %s is not linked to the run it continued as", info.RunID)
+		}
+	}
+	if chains == 0 {
+		t.Fatal("no generated executions continued as new")
+	}
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/proto"
+)
+
+// generateWorkflows returns the executions of exporttest.Workflows, which tests in this package cannot import since
+// exporttest imports export
+func generateWorkflows(t *testing.T, count int) []*export.WorkflowExecution {
+	t.Helper()
+	template := DefaultGenerateTemplate()
+	template.StartTime = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	generator, err := NewGenerator(template, 1)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	workflows := make([]*export.WorkflowExecution, count)
+	for i := range workflows {
+		if workflows[i], err = generator.Next(); err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+	}
+	return workflows
+}
+
+// serializeWorkflows returns the contents of an uncompressed export file holding workflows
+func serializeWorkflows(t *testing.T, workflows []*export.WorkflowExecution) []byte {
+	t.Helper()
+	data, err := SerializeExportedWorkflows(&export.WorkflowExecutions{Items: workflows})
+	if err != nil {
+		t.Fatalf("SerializeExportedWorkflows: %v", err)
+	}
+	return data
+}
+
+// generateWorkflow returns the first generated execution that has the given status
+func generateWorkflow(t *testing.T, status enumspb.WorkflowExecutionStatus) *export.WorkflowExecution {
+	t.Helper()
+	for _, workflow := range generateWorkflows(t, 200) {
+		if GetWorkflowStatus(workflow) == status {
+			return workflow
+		}
+	}
+	t.Fatalf("no generated workflow with status %s", status)
+	return nil
+}
+
+// indexedPayload returns a search attribute payload of the given type holding the JSON encoded data, as the server
+// records it
+func indexedPayload(attributeType, data string) *common.Payload {
+	return &common.Payload{
+		Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingJSON), "type": []byte(attributeType)},
+		Data:     []byte(data),
+	}
+}
+
+// resetWorkflow returns a reset run of base the way the server records it: the history of base up to its first
+// workflow task started event, followed by that workflow task failed with the reset cause and the remaining events of
+// base. The start event, and so the original run ID, is copied from base
+func resetWorkflow(t *testing.T, base *export.WorkflowExecution, newRunID string) *export.WorkflowExecution {
+	t.Helper()
+	reset := proto.Clone(base).(*export.WorkflowExecution)
+	events := reset.GetHistory().GetEvents()
+	baseRunID := events[0].GetWorkflowExecutionStartedEventAttributes().GetOriginalExecutionRunId()
+
+	for i, event := range events {
+		if event.GetEventType() != enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED {
+			continue
+		}
+		failed := &historypb.HistoryEvent{
+			EventTime: event.GetEventTime(),
+			EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED,
+			Attributes: &historypb.HistoryEvent_WorkflowTaskFailedEventAttributes{
+				WorkflowTaskFailedEventAttributes: &historypb.WorkflowTaskFailedEventAttributes{
+					Cause:     enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW,
+					BaseRunId: baseRunID,
+					NewRunId:  newRunID,
+				},
+			},
+		}
+		events = append(events[:i+1:i+1], append([]*historypb.HistoryEvent{failed}, events[i+1:]...)...)
+		for j, event := range events {
+			event.EventId = int64(j + 1)
+		}
+		reset.History.Events = events
+		return reset
+	}
+	t.Fatalf("workflow has no workflow task started event")
+	return nil
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+// ExecutionInfo is a structured summary of an exported workflow execution
+type ExecutionInfo struct {
+	WorkflowID   string
+	WorkflowType string
+	// RunID is the run ID of the execution. It differs from OriginalRunID when the execution was reset
+	RunID string
+	// OriginalRunID is the run ID the execution was started with
+	OriginalRunID string
+	// FirstRunID is the run ID of the first execution in the chain of retries and continue-as-new executions
+	FirstRunID string
+	// ContinuedFromRunID is the run ID of the execution this execution continued as new from, if any
+	ContinuedFromRunID string
+	// NewRunID is the run ID of the execution started when this execution continued as new or was retried, if any
+	NewRunID string
+
+	// ParentNamespace and ParentExecution identify the parent workflow of a child workflow execution
+	ParentNamespace string
+	ParentExecution *common.WorkflowExecution
+
+	TaskQueue string
+	StartTime time.Time
+	// CloseTime is the time of the close event, zero if the execution is still running
+	CloseTime time.Time
+	Status    enumspb.WorkflowExecutionStatus
+	// Memo and SearchAttributes hold the values recorded on the start event
+	Memo             *common.Memo
+	SearchAttributes *common.SearchAttributes
+	EventCount       int
+}
+
+// GetExportedWorkflowExecutionInfo returns a structured summary of an exported workflow execution
+func GetExportedWorkflowExecutionInfo(workflow *export.WorkflowExecution) (*ExecutionInfo, error) {
+	startAttributes, err := GetWorkflowStartedEventAttributes(workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	events := workflow.GetHistory().GetEvents()
+	info := &ExecutionInfo{
+		WorkflowID:         startAttributes.GetWorkflowId(),
+		WorkflowType:       startAttributes.GetWorkflowType().GetName(),
+		RunID:              startAttributes.GetOriginalExecutionRunId(),
+		OriginalRunID:      startAttributes.GetOriginalExecutionRunId(),
+		FirstRunID:         startAttributes.GetFirstExecutionRunId(),
+		ContinuedFromRunID: startAttributes.GetContinuedExecutionRunId(),
+		ParentNamespace:    startAttributes.GetParentWorkflowNamespace(),
+		ParentExecution:    startAttributes.GetParentWorkflowExecution(),
+		TaskQueue:          startAttributes.GetTaskQueue().GetName(),
+		StartTime:          events[0].GetEventTime().AsTime(),
+		Status:             GetWorkflowStatus(workflow),
+		Memo:               startAttributes.GetMemo(),
+		SearchAttributes:   startAttributes.GetSearchAttributes(),
+		EventCount:         len(events),
+	}
+
+	// A reset fails the current workflow task and records the run ID of the new execution
+	for _, event := range events {
+		attributes := event.GetWorkflowTaskFailedEventAttributes()
+		if attributes.GetCause() == enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW && attributes.GetNewRunId() != "" {
+			info.RunID = attributes.GetNewRunId()
+		}
+	}
+
+	if info.Status != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		closeEvent := events[len(events)-1]
+		info.CloseTime = closeEvent.GetEventTime().AsTime()
+		switch closeEvent.GetEventType() {
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+			info.NewRunID = closeEvent.GetWorkflowExecutionCompletedEventAttributes().GetNewExecutionRunId()
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+			info.NewRunID = closeEvent.GetWorkflowExecutionFailedEventAttributes().GetNewExecutionRunId()
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+			info.NewRunID = closeEvent.GetWorkflowExecutionTimedOutEventAttributes().GetNewExecutionRunId()
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+			info.NewRunID = closeEvent.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId()
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"io"
+	"iter"
+
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Executions returns an iterator over the workflow executions in the export read from r, decoded one at a time with a
+// Reader. A decoding error is yielded with a nil execution and ends the iteration. Breaking out of the loop releases
+// the decompression resources but does not close r
+//
+//	for workflow, err := range export.Executions(file) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Executions(r io.Reader) iter.Seq2[*export.WorkflowExecution, error] {
+	return func(yield func(*export.WorkflowExecution, error) bool) {
+		reader := NewReader(r)
+		defer reader.Close()
+		for {
+			workflow, err := reader.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(workflow, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Events returns an iterator over the history events of an exported workflow execution
+func Events(workflow *export.WorkflowExecution) iter.Seq[*historypb.HistoryEvent] {
+	return func(yield func(*historypb.HistoryEvent) bool) {
+		for _, event := range workflow.GetHistory().GetEvents() {
+			if !yield(event) {
+				return
+			}
+		}
+	}
+}
+
+// EventAttributes returns an iterator over the events of an exported workflow execution that have the attributes
+// returned by get, yielding each event with its attributes. get is usually a generated getter such as
+// (*historypb.HistoryEvent).GetTimerFiredEventAttributes
+func EventAttributes[T proto.Message](workflow *export.WorkflowExecution, get func(*historypb.HistoryEvent) T) iter.Seq2[*historypb.HistoryEvent, T] {
+	return func(yield func(*historypb.HistoryEvent, T) bool) {
+		for _, event := range workflow.GetHistory().GetEvents() {
+			// Getters return a nil pointer when the event holds other attributes
+			if attributes := get(event); attributes.ProtoReflect().IsValid() {
+				if !yield(event, attributes) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ActivityTaskScheduledEvents returns an iterator over the ActivityTaskScheduled events of an execution
+func ActivityTaskScheduledEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.ActivityTaskScheduledEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetActivityTaskScheduledEventAttributes)
+}
+
+// ActivityTaskCompletedEvents returns an iterator over the ActivityTaskCompleted events of an execution
+func ActivityTaskCompletedEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.ActivityTaskCompletedEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetActivityTaskCompletedEventAttributes)
+}
+
+// ActivityTaskFailedEvents returns an iterator over the ActivityTaskFailed events of an execution
+func ActivityTaskFailedEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.ActivityTaskFailedEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetActivityTaskFailedEventAttributes)
+}
+
+// TimerStartedEvents returns an iterator over the TimerStarted events of an execution
+func TimerStartedEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.TimerStartedEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetTimerStartedEventAttributes)
+}
+
+// SignaledEvents returns an iterator over the WorkflowExecutionSignaled events of an execution
+func SignaledEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.WorkflowExecutionSignaledEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetWorkflowExecutionSignaledEventAttributes)
+}
+
+// ChildWorkflowInitiatedEvents returns an iterator over the StartChildWorkflowExecutionInitiated events of an execution
+func ChildWorkflowInitiatedEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.StartChildWorkflowExecutionInitiatedEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetStartChildWorkflowExecutionInitiatedEventAttributes)
+}
+
+// MarkerRecordedEvents returns an iterator over the MarkerRecorded events of an execution, such as side effects,
+// mutable side effects, versions and local activities
+func MarkerRecordedEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.MarkerRecordedEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetMarkerRecordedEventAttributes)
+}
+
+// UpsertSearchAttributesEvents returns an iterator over the UpsertWorkflowSearchAttributes events of an execution
+func UpsertSearchAttributesEvents(workflow *export.WorkflowExecution) iter.Seq2[*historypb.HistoryEvent, *historypb.UpsertWorkflowSearchAttributesEventAttributes] {
+	return EventAttributes(workflow, (*historypb.HistoryEvent).GetUpsertWorkflowSearchAttributesEventAttributes)
+}
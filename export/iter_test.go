@@ -0,0 +1,120 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+)
+
+func TestExecutions(t *testing.T) {
+	workflows := generateWorkflows(t, 20)
+	data := serializeWorkflows(t, workflows)
+
+	var got []*export.WorkflowExecution
+	for workflow, err := range Executions(bytes.NewReader(gzipCompress(t, data))) {
+		if err != nil {
+			t.Fatalf("Executions: %v", err)
+		}
+		got = append(got, workflow)
+	}
+	assertWorkflowsEqual(t, got, workflows)
+
+	got = nil
+	for workflow, err := range Executions(bytes.NewReader(data)) {
+		if err != nil {
+			t.Fatalf("Executions: %v", err)
+		}
+		if got = append(got, workflow); len(got) == 5 {
+			break
+		}
+	}
+	assertWorkflowsEqual(t, got, workflows[:5])
+}
+
+func TestExecutionsTruncated(t *testing.T) {
+	workflows := generateWorkflows(t, 3)
+	data := serializeWorkflows(t, workflows)
+
+	var got []*export.WorkflowExecution
+	var errs []error
+	for workflow, err := range Executions(bytes.NewReader(data[:len(data)-1])) {
+		if err != nil {
+			if workflow != nil {
+				t.Error("Executions yielded an execution with an error")
+			}
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, workflow)
+	}
+	assertWorkflowsEqual(t, got, workflows[:2])
+	if len(errs) != 1 || !errors.Is(errs[0], io.ErrUnexpectedEOF) {
+		t.Errorf("Executions yielded errors %v, want one io.ErrUnexpectedEOF", errs)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	events := workflow.GetHistory().GetEvents()
+
+	var count int
+	for event := range Events(workflow) {
+		if event != events[count] {
+			t.Fatalf("event %d is not the history event at that position", count)
+		}
+		count++
+	}
+	if count != len(events) {
+		t.Errorf("Events yielded %d events, want %d", count, len(events))
+	}
+
+	var scheduled, completed int
+	for _, event := range events {
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			scheduled++
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			completed++
+		}
+	}
+	if scheduled == 0 {
+		t.Fatal("generated workflow has no activities")
+	}
+
+	var got int
+	for event, attributes := range ActivityTaskScheduledEvents(workflow) {
+		if event.GetEventType() != enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED || attributes != event.GetActivityTaskScheduledEventAttributes() {
+			t.Errorf("event %d is a %s event", event.GetEventId(), event.GetEventType())
+		}
+		got++
+	}
+	if got != scheduled {
+		t.Errorf("ActivityTaskScheduledEvents yielded %d events, want %d", got, scheduled)
+	}
+
+	got = 0
+	for _, attributes := range EventAttributes(workflow, (*historypb.HistoryEvent).GetActivityTaskCompletedEventAttributes) {
+		if attributes == nil {
+			t.Error("EventAttributes yielded nil attributes")
+		}
+		got++
+	}
+	if got != completed {
+		t.Errorf("EventAttributes yielded %d completed events, want %d", got, completed)
+	}
+
+	for event := range TimerStartedEvents(&export.WorkflowExecution{}) {
+		t.Errorf("TimerStartedEvents yielded event %d for an empty history", event.GetEventId())
+	}
+	for event := range ActivityTaskScheduledEvents(workflow) {
+		if event.GetEventType() != enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED {
+			t.Errorf("event %d is a %s event", event.GetEventId(), event.GetEventType())
+		}
+		break
+	}
+}
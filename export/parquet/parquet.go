@@ -0,0 +1,143 @@
+// Package parquet writes exported workflow executions as Parquet files for ingestion by data warehouse tooling
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	pq "github.com/parquet-go/parquet-go"
+	exportpb "go.temporal.io/api/export/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/temporalio/cloud-samples-go/export"
+)
+
+type (
+	// ExecutionRow is the schema of the execution-level file, one row per workflow execution. Column names and types
+	// are part of the file format, new columns are only ever added at the end. Optional columns are null
+	// when the value is empty
+	ExecutionRow struct {
+		WorkflowID   string `parquet:"workflow_id"`
+		RunID        string `parquet:"run_id"`
+		WorkflowType string `parquet:"workflow_type"`
+		TaskQueue    string `parquet:"task_queue"`
+		// Status is the status derived from the last event, for example "Completed" or "Running"
+		Status    string    `parquet:"status"`
+		StartTime time.Time `parquet:"start_time,timestamp(nanosecond)"`
+		// CloseTime is null if the execution is still running. The timestamp tag does not support pointers,
+		// but they are written as nanosecond timestamps by default
+		CloseTime *time.Time `parquet:"close_time,optional"`
+		// DurationMillis is the time from the start event to the close event, null if the execution is still running
+		DurationMillis     *int64 `parquet:"duration_ms,optional"`
+		EventCount         int64  `parquet:"event_count"`
+		OriginalRunID      string `parquet:"original_run_id"`
+		FirstRunID         string `parquet:"first_run_id"`
+		ContinuedFromRunID string `parquet:"continued_from_run_id,optional"`
+		NewRunID           string `parquet:"new_run_id,optional"`
+		ParentNamespace    string `parquet:"parent_namespace,optional"`
+		ParentWorkflowID   string `parquet:"parent_workflow_id,optional"`
+		ParentRunID        string `parquet:"parent_run_id,optional"`
+	}
+
+	// EventRow is the schema of the event-level file, one row per history event
+	EventRow struct {
+		WorkflowID   string    `parquet:"workflow_id"`
+		RunID        string    `parquet:"run_id"`
+		WorkflowType string    `parquet:"workflow_type"`
+		EventID      int64     `parquet:"event_id"`
+		EventTime    time.Time `parquet:"event_time,timestamp(nanosecond)"`
+		// EventType is the event type name, for example "ActivityTaskScheduled"
+		EventType string `parquet:"event_type"`
+		// Attributes is the complete event as protojson
+		Attributes string `parquet:"attributes,json"`
+	}
+
+	// Writer writes exported workflow executions to an execution-level and an event-level Parquet file
+	Writer struct {
+		executions *pq.GenericWriter[ExecutionRow]
+		events     *pq.GenericWriter[EventRow]
+	}
+)
+
+// NewWriter returns a Writer that writes execution rows to executions and event rows to events. The files are only
+// complete once Close is called
+func NewWriter(executions, events io.Writer) *Writer {
+	return &Writer{
+		executions: pq.NewGenericWriter[ExecutionRow](executions, pq.Compression(&pq.Zstd)),
+		events:     pq.NewGenericWriter[EventRow](events, pq.Compression(&pq.Zstd)),
+	}
+}
+
+// Write appends the workflow executions and their events to the files
+func (w *Writer) Write(workflows []*exportpb.WorkflowExecution) error {
+	executions := make([]ExecutionRow, 0, len(workflows))
+	var events []EventRow
+	for _, workflow := range workflows {
+		info, err := export.GetExportedWorkflowExecutionInfo(workflow)
+		if err != nil {
+			return fmt.Errorf("failed to convert workflow: %w", err)
+		}
+		executions = append(executions, newExecutionRow(info))
+
+		for _, event := range workflow.GetHistory().GetEvents() {
+			attributes, err := protojson.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to marshal event %d of workflow %s, run %s: %w", event.GetEventId(), info.WorkflowID, info.RunID, err)
+			}
+			events = append(events, EventRow{
+				WorkflowID:   info.WorkflowID,
+				RunID:        info.RunID,
+				WorkflowType: info.WorkflowType,
+				EventID:      event.GetEventId(),
+				EventTime:    event.GetEventTime().AsTime(),
+				EventType:    event.GetEventType().String(),
+				Attributes:   string(attributes),
+			})
+		}
+	}
+
+	if _, err := w.executions.Write(executions); err != nil {
+		return fmt.Errorf("failed to write executions: %w", err)
+	}
+	if _, err := w.events.Write(events); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+	return nil
+}
+
+// Close flushes buffered rows and writes the file footers. It does not close the underlying writers
+func (w *Writer) Close() error {
+	if err := w.executions.Close(); err != nil {
+		return fmt.Errorf("failed to write executions: %w", err)
+	}
+	if err := w.events.Close(); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+	return nil
+}
+
+func newExecutionRow(info *export.ExecutionInfo) ExecutionRow {
+	row := ExecutionRow{
+		WorkflowID:         info.WorkflowID,
+		RunID:              info.RunID,
+		WorkflowType:       info.WorkflowType,
+		TaskQueue:          info.TaskQueue,
+		Status:             info.Status.String(),
+		StartTime:          info.StartTime,
+		EventCount:         int64(info.EventCount),
+		OriginalRunID:      info.OriginalRunID,
+		FirstRunID:         info.FirstRunID,
+		ContinuedFromRunID: info.ContinuedFromRunID,
+		NewRunID:           info.NewRunID,
+		ParentNamespace:    info.ParentNamespace,
+		ParentWorkflowID:   info.ParentExecution.GetWorkflowId(),
+		ParentRunID:        info.ParentExecution.GetRunId(),
+	}
+	if !info.CloseTime.IsZero() {
+		closeTime := info.CloseTime
+		duration := closeTime.Sub(info.StartTime).Milliseconds()
+		row.CloseTime, row.DurationMillis = &closeTime, &duration
+	}
+	return row
+}
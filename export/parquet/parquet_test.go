@@ -0,0 +1,164 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	pq "github.com/parquet-go/parquet-go"
+	"go.temporal.io/api/common/v1"
+	exportpb "go.temporal.io/api/export/v1"
+
+	"github.com/temporalio/cloud-samples-go/export"
+	"github.com/temporalio/cloud-samples-go/internal/exporttest"
+)
+
+// The schemas below are part of the file format. A change that breaks these tests breaks downstream tables
+const (
+	executionSchema = `message ExecutionRow {
+	required binary workflow_id (STRING);
+	required binary run_id (STRING);
+	required binary workflow_type (STRING);
+	required binary task_queue (STRING);
+	required binary status (STRING);
+	required int64 start_time (TIMESTAMP(isAdjustedToUTC=true,unit=NANOS));
+	optional int64 close_time (TIMESTAMP(isAdjustedToUTC=true,unit=NANOS));
+	optional int64 duration_ms (INT(64,true));
+	required int64 event_count (INT(64,true));
+	required binary original_run_id (STRING);
+	required binary first_run_id (STRING);
+	optional binary continued_from_run_id (STRING);
+	optional binary new_run_id (STRING);
+	optional binary parent_namespace (STRING);
+	optional binary parent_workflow_id (STRING);
+	optional binary parent_run_id (STRING);
+}`
+	eventSchema = `message EventRow {
+	required binary workflow_id (STRING);
+	required binary run_id (STRING);
+	required binary workflow_type (STRING);
+	required int64 event_id (INT(64,true));
+	required int64 event_time (TIMESTAMP(isAdjustedToUTC=true,unit=NANOS));
+	required binary event_type (STRING);
+	required binary attributes (JSON);
+}`
+)
+
+func openFile(t *testing.T, data []byte) *pq.File {
+	t.Helper()
+	file, err := pq.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	return file
+}
+
+// readRows returns every row of the file with its values indexed by column name
+func readRows(t *testing.T, file *pq.File) []map[string]pq.Value {
+	t.Helper()
+	var columns []string
+	for _, path := range file.Schema().Columns() {
+		columns = append(columns, path[0])
+	}
+	var rows []map[string]pq.Value
+	for _, rowGroup := range file.RowGroups() {
+		reader := rowGroup.Rows()
+		buf := make([]pq.Row, 16)
+		for {
+			n, err := reader.ReadRows(buf)
+			for _, row := range buf[:n] {
+				values := map[string]pq.Value{}
+				for _, value := range row {
+					values[columns[value.Column()]] = value
+				}
+				rows = append(rows, values)
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("ReadRows: %v", err)
+			}
+		}
+		reader.Close()
+	}
+	return rows
+}
+
+func TestWriterSchema(t *testing.T) {
+	workflows := exporttest.Workflows(t, 2)
+	child, running := workflows[0], workflows[1]
+	started := child.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes()
+	started.ParentWorkflowNamespace = "parent-namespace"
+	started.ParentWorkflowExecution = &common.WorkflowExecution{WorkflowId: "parent-workflow", RunId: "parent-run"}
+	running.History.Events = running.History.Events[:len(running.History.Events)-1]
+
+	var executions, events bytes.Buffer
+	writer := NewWriter(&executions, &events)
+	if err := writer.Write([]*exportpb.WorkflowExecution{child, running}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	executionFile, eventFile := openFile(t, executions.Bytes()), openFile(t, events.Bytes())
+	if got := executionFile.Schema().String(); got != executionSchema {
+		t.Errorf("executions schema changed:\n%s\nwant:\n%s", got, executionSchema)
+	}
+	if got := eventFile.Schema().String(); got != eventSchema {
+		t.Errorf("events schema changed:\n%s\nwant:\n%s", got, eventSchema)
+	}
+
+	rows := readRows(t, executionFile)
+	if len(rows) != 2 {
+		t.Fatalf("executions file has %d rows, want 2", len(rows))
+	}
+	childInfo, err := export.GetExportedWorkflowExecutionInfo(child)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"run_id":             childInfo.RunID,
+		"close_time":         childInfo.CloseTime.UnixNano(),
+		"duration_ms":        childInfo.CloseTime.Sub(childInfo.StartTime).Milliseconds(),
+		"parent_namespace":   "parent-namespace",
+		"parent_workflow_id": "parent-workflow",
+		"parent_run_id":      "parent-run",
+	}
+	for column, value := range want {
+		got := rows[0][column]
+		if got.IsNull() {
+			t.Errorf("child %s is null, want %v", column, value)
+			continue
+		}
+		switch value := value.(type) {
+		case string:
+			if got.String() != value {
+				t.Errorf("child %s = %s, want %s", column, got, value)
+			}
+		case int64:
+			if got.Int64() != value {
+				t.Errorf("child %s = %d, want %d", column, got.Int64(), value)
+			}
+		}
+	}
+	for _, column := range []string{"close_time", "duration_ms", "parent_namespace", "parent_workflow_id", "parent_run_id"} {
+		if !rows[1][column].IsNull() {
+			t.Errorf("running execution %s = %v, want null", column, rows[1][column])
+		}
+	}
+	if rows[1]["status"].String() != "Running" {
+		t.Errorf("running execution status = %s, want Running", rows[1]["status"])
+	}
+
+	eventRows := readRows(t, eventFile)
+	if want := len(child.GetHistory().GetEvents()) + len(running.GetHistory().GetEvents()); len(eventRows) != want {
+		t.Fatalf("events file has %d rows, want %d", len(eventRows), want)
+	}
+	var event map[string]any
+	if err := json.Unmarshal(eventRows[0]["attributes"].ByteArray(), &event); err != nil || event["eventType"] != "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED" {
+		t.Errorf("first event attributes = %s, want the start event as protojson", eventRows[0]["attributes"])
+	}
+}
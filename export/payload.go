@@ -0,0 +1,209 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/api/proxy"
+	"go.temporal.io/api/temporalproto"
+	"go.temporal.io/sdk/converter"
+)
+
+type (
+	// PayloadRendererOptions configure a PayloadRenderer
+	PayloadRendererOptions struct {
+		// DataConverter converts decoded payloads into values, defaults to converter.GetDefaultDataConverter()
+		DataConverter converter.DataConverter
+		// Codecs decode payloads before they are converted, for example to decrypt or decompress them. Like
+		// converter.NewCodecDataConverter, codecs are applied first to last when decoding
+		Codecs []converter.PayloadCodec
+		// BatchSize is the maximum number of payloads passed to the codecs at once, defaults to defaultPayloadBatchSize
+		BatchSize int
+	}
+
+	// PayloadRenderer renders the payloads in exported workflow executions, such as workflow and activity inputs and
+	// results, as readable values instead of base64 encoded blobs
+	PayloadRenderer struct {
+		dataConverter converter.DataConverter
+		codecs        []converter.PayloadCodec
+		batchSize     int
+	}
+)
+
+const defaultPayloadBatchSize = 1000
+
+// NewPayloadRenderer returns a PayloadRenderer configured with options
+func NewPayloadRenderer(options PayloadRendererOptions) *PayloadRenderer {
+	dataConverter := options.DataConverter
+	if dataConverter == nil {
+		dataConverter = converter.GetDefaultDataConverter()
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPayloadBatchSize
+	}
+	return &PayloadRenderer{
+		dataConverter: dataConverter,
+		codecs:        options.Codecs,
+		batchSize:     batchSize,
+	}
+}
+
+// DecodePayloads returns a copy of the workflow execution with every payload passed through the configured codecs.
+// Search attributes are never encoded by codecs and are left untouched
+func (r *PayloadRenderer) DecodePayloads(ctx context.Context, workflow *export.WorkflowExecution) (*export.WorkflowExecution, error) {
+	decoded, err := r.DecodeWorkflows(ctx, []*export.WorkflowExecution{workflow})
+	if err != nil {
+		return nil, err
+	}
+	return decoded[0], nil
+}
+
+// DecodeWorkflows is like DecodePayloads for several workflow executions. The payloads of all executions are collected
+// and passed to the codecs in batches of at most BatchSize payloads, which keeps the number of requests low when a
+// codec calls out to a remote codec server
+func (r *PayloadRenderer) DecodeWorkflows(ctx context.Context, workflows []*export.WorkflowExecution) ([]*export.WorkflowExecution, error) {
+	decoded := make([]*export.WorkflowExecution, len(workflows))
+	for i, workflow := range workflows {
+		decoded[i] = proto.Clone(workflow).(*export.WorkflowExecution)
+	}
+	if len(r.codecs) == 0 {
+		return decoded, nil
+	}
+
+	// Collect every payload first. Payloads are matched back up by identity rather than position because map fields
+	// such as memos are not visited in a stable order
+	var payloads []*common.Payload
+	collect := proxy.VisitPayloadsOptions{
+		SkipSearchAttributes: true,
+		Visitor: func(_ *proxy.VisitPayloadsContext, p []*common.Payload) ([]*common.Payload, error) {
+			payloads = append(payloads, p...)
+			return p, nil
+		},
+	}
+	for _, workflow := range decoded {
+		if err := proxy.VisitPayloads(ctx, workflow, collect); err != nil {
+			return nil, err
+		}
+	}
+
+	replacements := make(map[*common.Payload]*common.Payload, len(payloads))
+	for start := 0; start < len(payloads); start += r.batchSize {
+		batch := payloads[start:min(start+r.batchSize, len(payloads))]
+		result, err := r.decode(batch)
+		if err != nil {
+			return nil, err
+		}
+		for i, payload := range batch {
+			replacements[payload] = result[i]
+		}
+	}
+
+	replace := proxy.VisitPayloadsOptions{
+		SkipSearchAttributes: true,
+		Visitor: func(_ *proxy.VisitPayloadsContext, p []*common.Payload) ([]*common.Payload, error) {
+			result := make([]*common.Payload, len(p))
+			for i, payload := range p {
+				result[i] = replacements[payload]
+			}
+			return result, nil
+		},
+	}
+	for _, workflow := range decoded {
+		if err := proxy.VisitPayloads(ctx, workflow, replace); err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
+
+func (r *PayloadRenderer) decode(payloads []*common.Payload) ([]*common.Payload, error) {
+	decoded := payloads
+	var err error
+	for _, codec := range r.codecs {
+		if decoded, err = codec.Decode(decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode payloads: %w", err)
+		}
+	}
+	if len(decoded) != len(payloads) {
+		return nil, fmt.Errorf("failed to decode payloads: codecs returned %d payloads, expected %d", len(decoded), len(payloads))
+	}
+	return decoded, nil
+}
+
+// RenderPayload converts a payload that has already been decoded by the codecs into a value that can be marshaled to
+// JSON. JSON and binary null payloads are converted with the data converter, protobuf payloads are converted through
+// their registered message type. Payloads that cannot be converted are rendered with the data converter's ToString
+func (r *PayloadRenderer) RenderPayload(payload *common.Payload) any {
+	if messageType := string(payload.GetMetadata()[converter.MetadataMessageType]); messageType != "" {
+		if mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(messageType)); err == nil {
+			msg := mt.New().Interface()
+			if err := r.dataConverter.FromPayload(payload, msg); err == nil {
+				if b, err := protojson.Marshal(msg); err == nil {
+					var value any
+					if err := json.Unmarshal(b, &value); err == nil {
+						return value
+					}
+				}
+			}
+		}
+	}
+
+	var value any
+	if err := r.dataConverter.FromPayload(payload, &value); err == nil {
+		return value
+	}
+	return r.dataConverter.ToString(payload)
+}
+
+// MarshalWorkflow marshals the workflow execution to protojson with every payload decoded and replaced by its rendered
+// value. An empty indent produces compact output
+func (r *PayloadRenderer) MarshalWorkflow(ctx context.Context, workflow *export.WorkflowExecution, indent string) ([]byte, error) {
+	decoded, err := r.DecodePayloads(ctx, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	err = proxy.VisitPayloads(ctx, decoded, proxy.VisitPayloadsOptions{
+		Visitor: func(_ *proxy.VisitPayloadsContext, payloads []*common.Payload) ([]*common.Payload, error) {
+			rendered := make([]*common.Payload, len(payloads))
+			for i, payload := range payloads {
+				data, err := json.Marshal(r.RenderPayload(payload))
+				if err != nil {
+					return nil, fmt.Errorf("failed to render payload: %w", err)
+				}
+				rendered[i] = &common.Payload{
+					Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingJSON)},
+					Data:     data,
+				}
+			}
+			return rendered, nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// With payload shorthand enabled, plain JSON payloads are written as their JSON value
+	return temporalproto.CustomJSONMarshalOptions{
+		Indent:   indent,
+		Metadata: map[string]any{common.EnablePayloadShorthandMetadataKey: true},
+	}.Marshal(decoded)
+}
+
+// FormatWorkflow is like the package level FormatWorkflow, but renders payloads as readable values
+func (r *PayloadRenderer) FormatWorkflow(ctx context.Context, workflow *export.WorkflowExecution) (string, error) {
+	b, err := r.MarshalWorkflow(ctx, workflow, "\t")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
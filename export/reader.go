@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/api/export/v1"
+)
+
+// workflowExecutionsItemsField is the field number of WorkflowExecutions.items in the export proto
+const workflowExecutionsItemsField protowire.Number = 1
+
+// Reader reads workflow executions from an export file one at a time. Unlike DeserializeExportedWorkflows it never
+// holds more than a single execution in memory, which makes it suitable for processing very large export files
+type Reader struct {
+	src          io.Reader
+	r            *bufio.Reader
+	decompressor io.Closer
+	count        int
+}
+
+// NewReader returns a Reader that walks the protobuf wire format of the WorkflowExecutions message read from r. Gzip
+// and zstd compressed input is detected and decompressed transparently
+func NewReader(r io.Reader) *Reader {
+	return &Reader{src: r}
+}
+
+// Next returns the next workflow execution in the export. It returns io.EOF once all executions have been read
+func (r *Reader) Next() (*export.WorkflowExecution, error) {
+	if r.r == nil {
+		decompressed, err := Decompress(r.src)
+		if err != nil {
+			return nil, err
+		}
+		r.r = bufio.NewReader(decompressed)
+		r.decompressor = decompressed
+	}
+
+	for {
+		tag, err := binary.ReadUvarint(r.r)
+		if err == io.EOF {
+			r.Close()
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, r.wrapErr(err)
+		}
+
+		num, typ := protowire.DecodeTag(tag)
+		if num == workflowExecutionsItemsField && typ == protowire.BytesType {
+			data, err := r.readBytes()
+			if err != nil {
+				return nil, r.wrapErr(err)
+			}
+			var workflow export.WorkflowExecution
+			if err := proto.Unmarshal(data, &workflow); err != nil {
+				return nil, r.wrapErr(err)
+			}
+			r.count++
+			return &workflow, nil
+		}
+
+		// Skip any field that isn't a workflow execution so that newer export formats remain readable
+		if err := r.skipField(typ); err != nil {
+			return nil, r.wrapErr(err)
+		}
+	}
+}
+
+// Close releases the resources used to decompress the export. It does not close the underlying reader, and is only
+// needed when reading stops before Next returns io.EOF
+func (r *Reader) Close() error {
+	if r.decompressor == nil {
+		return nil
+	}
+	err := r.decompressor.Close()
+	r.decompressor = nil
+	return err
+}
+
+// Count returns the number of workflow executions read so far
+func (r *Reader) Count() int {
+	return r.count
+}
+
+func (r *Reader) readBytes() ([]byte, error) {
+	length, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	if length > math.MaxInt32 {
+		return nil, fmt.Errorf("field length %d exceeds the maximum protobuf message size", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *Reader) skipField(typ protowire.Type) error {
+	var err error
+	switch typ {
+	case protowire.VarintType:
+		_, err = binary.ReadUvarint(r.r)
+	case protowire.Fixed32Type:
+		_, err = r.r.Discard(4)
+	case protowire.Fixed64Type:
+		_, err = r.r.Discard(8)
+	case protowire.BytesType:
+		var length uint64
+		length, err = binary.ReadUvarint(r.r)
+		if err == nil {
+			_, err = io.CopyN(io.Discard, r.r, int64(length))
+		}
+	default:
+		err = fmt.Errorf("unsupported wire type %d", typ)
+	}
+	return err
+}
+
+func (r *Reader) wrapErr(err error) error {
+	if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("failed to decode workflow %d, export file is truncated: %w", r.count+1, io.ErrUnexpectedEOF)
+	}
+	return fmt.Errorf("failed to decode workflow %d: %w", r.count+1, err)
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"go.temporal.io/api/export/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// readAll reads every workflow execution from data with a Reader
+func readAll(data []byte) ([]*export.WorkflowExecution, error) {
+	reader := NewReader(bytes.NewReader(data))
+	var workflows []*export.WorkflowExecution
+	for {
+		workflow, err := reader.Next()
+		if err == io.EOF {
+			return workflows, nil
+		}
+		if err != nil {
+			return workflows, err
+		}
+		workflows = append(workflows, workflow)
+	}
+}
+
+func assertWorkflowsEqual(t *testing.T, got, want []*export.WorkflowExecution) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("read %d workflows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("workflow %d differs from the serialized workflow", i)
+		}
+	}
+}
+
+func TestReaderRoundTrip(t *testing.T) {
+	workflows := generateWorkflows(t, 25)
+	data := serializeWorkflows(t, workflows)
+
+	got, err := readAll(data)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	assertWorkflowsEqual(t, got, workflows)
+
+	deserialized, err := DeserializeExportedWorkflows(data)
+	if err != nil {
+		t.Fatalf("DeserializeExportedWorkflows: %v", err)
+	}
+	assertWorkflowsEqual(t, deserialized.GetItems(), workflows)
+
+	reader := NewReader(bytes.NewReader(data))
+	for range 3 {
+		if _, err := reader.Next(); err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+	}
+	if reader.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", reader.Count())
+	}
+	if err := reader.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestReaderEmpty(t *testing.T) {
+	workflows, err := readAll(nil)
+	if err != nil || len(workflows) != 0 {
+		t.Errorf("reading an empty export returned %d workflows and error %v", len(workflows), err)
+	}
+}
+
+func TestReaderSkipsUnknownFields(t *testing.T) {
+	workflows := generateWorkflows(t, 2)
+	var data []byte
+	data = protowire.AppendTag(data, 7, protowire.VarintType)
+	data = protowire.AppendVarint(data, 42)
+	data = protowire.AppendTag(data, 8, protowire.BytesType)
+	data = protowire.AppendBytes(data, []byte("newer export format"))
+	data = append(data, serializeWorkflows(t, workflows[:1])...)
+	data = protowire.AppendTag(data, 9, protowire.Fixed64Type)
+	data = protowire.AppendFixed64(data, 1)
+	data = append(data, serializeWorkflows(t, workflows[1:])...)
+
+	got, err := readAll(data)
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	assertWorkflowsEqual(t, got, workflows)
+}
+
+func TestReaderTruncated(t *testing.T) {
+	workflows := generateWorkflows(t, 3)
+	data := serializeWorkflows(t, workflows)
+	first := len(serializeWorkflows(t, workflows[:1]))
+
+	for _, size := range []int{first + 1, first + 3, len(data) - 1} {
+		got, err := readAll(data[:size])
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("reading %d of %d bytes returned error %v, want io.ErrUnexpectedEOF", size, len(data), err)
+		}
+		if len(got) == 0 || !proto.Equal(got[0], workflows[0]) {
+			t.Errorf("reading %d of %d bytes did not return the complete workflows before the truncation", size, len(data))
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+const (
+	// RedactScrub replaces strings with RedactedValue, clears bytes and other scalars, and replaces payloads with null
+	// payloads
+	RedactScrub RedactionAction = "scrub"
+	// RedactHash replaces strings, bytes and payload data with their SHA-256 hash, or their HMAC-SHA256 when the rules
+	// set a HashKey. Hashing keeps equal values equal, so redacted executions can still be correlated. Other scalars
+	// cannot hold a hash and are cleared as with RedactScrub
+	RedactHash RedactionAction = "hash"
+
+	// RedactedValue replaces scrubbed strings
+	RedactedValue = "REDACTED"
+)
+
+var (
+	payloadMessage          = (&common.Payload{}).ProtoReflect().Descriptor().FullName()
+	searchAttributesMessage = (&common.SearchAttributes{}).ProtoReflect().Descriptor().FullName()
+	memoMessage             = (&common.Memo{}).ProtoReflect().Descriptor().FullName()
+)
+
+type (
+	// RedactionAction is what a redaction rule does to the values it matches
+	RedactionAction string
+
+	// RedactionRule selects values to redact and how. What Match holds depends on the list the rule is in
+	RedactionRule struct {
+		Match  string          `json:"match"`
+		Action RedactionAction `json:"action"`
+	}
+
+	// RedactionRules configure a Redactor, usually loaded from a JSON rules file with LoadRedactionRules
+	RedactionRules struct {
+		// HashKey switches hashing from SHA-256 to HMAC-SHA256 with this key, which prevents recovering short values
+		// such as email addresses by hashing guesses
+		HashKey string `json:"hashKey,omitempty"`
+		// Fields match field paths relative to each history event, such as
+		// "workflow_execution_started_event_attributes.input". Path segments are field names in snake case or JSON
+		// camel case, "*" matches any single field and "**" matches any number of fields. Matching a message field
+		// redacts every value in it
+		Fields []RedactionRule `json:"fields,omitempty"`
+		// SearchAttributes match search attribute names wherever search attributes are recorded
+		SearchAttributes []RedactionRule `json:"searchAttributes,omitempty"`
+		// MemoKeys match memo keys wherever memos are recorded
+		MemoKeys []RedactionRule `json:"memoKeys,omitempty"`
+		// PayloadEncodings match the encoding metadata of payloads, such as "json/plain" or "binary/encrypted"
+		PayloadEncodings []RedactionRule `json:"payloadEncodings,omitempty"`
+	}
+
+	// Redactor removes sensitive data from exported workflow executions according to a set of rules
+	Redactor struct {
+		hashKey          []byte
+		fields           []fieldRule
+		searchAttributes map[string]RedactionAction
+		memoKeys         map[string]RedactionAction
+		payloadEncodings map[string]RedactionAction
+	}
+
+	fieldRule struct {
+		segments []string
+		action   RedactionAction
+	}
+)
+
+// LoadRedactionRules reads redaction rules from a JSON file
+func LoadRedactionRules(path string) (*RedactionRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redaction rules: %w", err)
+	}
+	var rules RedactionRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse redaction rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// NewRedactor returns a Redactor for the rules, or an error if a rule is invalid
+func NewRedactor(rules *RedactionRules) (*Redactor, error) {
+	r := &Redactor{
+		hashKey:          []byte(rules.HashKey),
+		searchAttributes: map[string]RedactionAction{},
+		memoKeys:         map[string]RedactionAction{},
+		payloadEncodings: map[string]RedactionAction{},
+	}
+	for _, rule := range rules.Fields {
+		if err := validateRedactionRule("field", rule); err != nil {
+			return nil, err
+		}
+		segments := strings.Split(rule.Match, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field redaction rule %q: empty path segment", rule.Match)
+			}
+		}
+		r.fields = append(r.fields, fieldRule{segments: segments, action: rule.Action})
+	}
+	for _, list := range []struct {
+		kind  string
+		rules []RedactionRule
+		names map[string]RedactionAction
+	}{
+		{"search attribute", rules.SearchAttributes, r.searchAttributes},
+		{"memo key", rules.MemoKeys, r.memoKeys},
+		{"payload encoding", rules.PayloadEncodings, r.payloadEncodings},
+	} {
+		for _, rule := range list.rules {
+			if err := validateRedactionRule(list.kind, rule); err != nil {
+				return nil, err
+			}
+			list.names[rule.Match] = rule.Action
+		}
+	}
+	return r, nil
+}
+
+func validateRedactionRule(kind string, rule RedactionRule) error {
+	if rule.Match == "" {
+		return fmt.Errorf("invalid %s redaction rule: match is empty", kind)
+	}
+	if rule.Action != RedactScrub && rule.Action != RedactHash {
+		return fmt.Errorf("invalid %s redaction rule %q: action must be %q or %q, got %q", kind, rule.Match, RedactScrub, RedactHash, rule.Action)
+	}
+	return nil
+}
+
+// Redact returns a copy of the workflow execution with every value matched by the rules redacted. The result is still a
+// valid workflow execution, so it can be written to a new export file with a Writer
+func (r *Redactor) Redact(workflow *export.WorkflowExecution) *export.WorkflowExecution {
+	redacted := proto.Clone(workflow).(*export.WorkflowExecution)
+	for _, event := range redacted.GetHistory().GetEvents() {
+		r.redactMessage(event.ProtoReflect(), nil)
+	}
+	return redacted
+}
+
+// redactMessage applies the rules to the fields of m, whose path from the history event is path
+func (r *Redactor) redactMessage(m protoreflect.Message, path []protoreflect.FieldDescriptor) {
+	if m.Descriptor().FullName() == payloadMessage {
+		payload := m.Interface().(*common.Payload)
+		if action, ok := r.payloadEncodings[string(payload.GetMetadata()[converter.MetadataEncoding])]; ok {
+			r.redactPayload(payload, action)
+		}
+		return
+	}
+
+	var names map[string]RedactionAction
+	switch m.Descriptor().FullName() {
+	case searchAttributesMessage:
+		names = r.searchAttributes
+	case memoMessage:
+		names = r.memoKeys
+	}
+
+	// Collect the fields first, the message must not be modified while ranging over it
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		fieldPath := append(path[:len(path):len(path)], fd)
+		if action, ok := r.matchField(fieldPath); ok {
+			r.redactField(m, fd, action)
+			continue
+		}
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := m.Get(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				r.redactMessage(list.Get(i).Message(), fieldPath)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			m.Get(fd).Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				// Search attribute and memo payloads are matched by their name before any other rule
+				if action, ok := names[key.String()]; ok {
+					r.redactPayload(value.Message().Interface().(*common.Payload), action)
+				} else {
+					r.redactMessage(value.Message(), fieldPath)
+				}
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			r.redactMessage(m.Get(fd).Message(), fieldPath)
+		}
+	}
+}
+
+// matchField returns the action of the first field rule that matches path
+func (r *Redactor) matchField(path []protoreflect.FieldDescriptor) (RedactionAction, bool) {
+	for _, rule := range r.fields {
+		if matchPath(rule.segments, path) {
+			return rule.action, true
+		}
+	}
+	return "", false
+}
+
+func matchPath(segments []string, path []protoreflect.FieldDescriptor) bool {
+	if len(segments) == 0 {
+		return len(path) == 0
+	}
+	if segments[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchPath(segments[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	segment, fd := segments[0], path[0]
+	if segment != "*" && segment != string(fd.Name()) && segment != fd.JSONName() {
+		return false
+	}
+	return matchPath(segments[1:], path[1:])
+}
+
+// redactField redacts every value held by a field of m
+func (r *Redactor) redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor, action RedactionAction) {
+	switch {
+	case fd.IsList():
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			list.Set(i, r.redactValue(fd, list.Get(i), action))
+		}
+	case fd.IsMap():
+		values := m.Get(fd).Map()
+		var keys []protoreflect.MapKey
+		values.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, key)
+			return true
+		})
+		for _, key := range keys {
+			values.Set(key, r.redactValue(fd.MapValue(), values.Get(key), action))
+		}
+	default:
+		m.Set(fd, r.redactValue(fd, m.Get(fd), action))
+	}
+}
+
+// redactValue returns the redacted form of a single value of fd. Messages are redacted in place
+func (r *Redactor) redactValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, action RedactionAction) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if action == RedactHash {
+			return protoreflect.ValueOfString(r.hashString([]byte(v.String())))
+		}
+		return protoreflect.ValueOfString(RedactedValue)
+	case protoreflect.BytesKind:
+		if action == RedactHash {
+			return protoreflect.ValueOfBytes(r.hash(v.Bytes()))
+		}
+		return protoreflect.ValueOfBytes(nil)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		r.redactAll(v.Message(), action)
+		return v
+	}
+	// Numbers, booleans and enums cannot hold a hash, so both actions scrub them rather than leak the original value
+	return zeroValue(fd.Kind())
+}
+
+func zeroValue(kind protoreflect.Kind) protoreflect.Value {
+	switch kind {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(false)
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(0)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(0)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(0)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(0)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(0)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(0)
+	default:
+		return protoreflect.ValueOfFloat64(0)
+	}
+}
+
+// redactAll redacts every value in m
+func (r *Redactor) redactAll(m protoreflect.Message, action RedactionAction) {
+	if m.Descriptor().FullName() == payloadMessage {
+		r.redactPayload(m.Interface().(*common.Payload), action)
+		return
+	}
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	for _, fd := range fields {
+		r.redactField(m, fd, action)
+	}
+}
+
+// redactPayload replaces the data of a payload in place, keeping its metadata apart from the encoding. Scrubbed payloads
+// become null payloads, hashed payloads become JSON strings holding the hash of the original data, so both still
+// decode with the default data converter. Search attribute payloads keep their type: Keyword and Text attributes are
+// hashed into strings, KeywordList attributes element by element, and attributes of other types cannot hold a hash so
+// they are scrubbed
+func (r *Redactor) redactPayload(payload *common.Payload, action RedactionAction) {
+	if payload.Metadata == nil {
+		payload.Metadata = map[string][]byte{}
+	}
+	if action == RedactHash {
+		var data []byte
+		switch SearchAttributeType(payload) {
+		case "", SearchAttributeKeyword, SearchAttributeText:
+			data, _ = json.Marshal(r.hashString(payload.GetData()))
+		case SearchAttributeKeywordList:
+			data = r.hashKeywordList(payload.GetData())
+		}
+		if data != nil {
+			payload.Metadata[converter.MetadataEncoding] = []byte(converter.MetadataEncodingJSON)
+			payload.Data = data
+			return
+		}
+	}
+	payload.Metadata[converter.MetadataEncoding] = []byte(converter.MetadataEncodingNil)
+	payload.Data = nil
+}
+
+// hashKeywordList hashes every keyword of a KeywordList search attribute on its own, as the JSON encoded keyword so
+// that it hashes like the same value in a Keyword attribute. It returns nil if data is not a list of strings
+func (r *Redactor) hashKeywordList(data []byte) []byte {
+	var keywords []string
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return nil
+	}
+	for i, keyword := range keywords {
+		encoded, _ := json.Marshal(keyword)
+		keywords[i] = r.hashString(encoded)
+	}
+	hashed, _ := json.Marshal(keywords)
+	return hashed
+}
+
+func (r *Redactor) hash(data []byte) []byte {
+	var h hash.Hash
+	if len(r.hashKey) > 0 {
+		h = hmac.New(sha256.New, r.hashKey)
+	} else {
+		h = sha256.New()
+	}
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// hashString returns the hash of data as a string that records how it was computed
+func (r *Redactor) hashString(data []byte) string {
+	prefix := "sha256:"
+	if len(r.hashKey) > 0 {
+		prefix = "hmac-sha256:"
+	}
+	return prefix + hex.EncodeToString(r.hash(data))
+}
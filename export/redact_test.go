@@ -0,0 +1,153 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestRedactKeepsPayloadsDecodable(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	started := workflow.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes()
+	started.SearchAttributes = &common.SearchAttributes{IndexedFields: map[string]*common.Payload{
+		"CustomerID":    indexedPayload(SearchAttributeKeyword, `"customer-1"`),
+		"CustomerTags":  indexedPayload(SearchAttributeKeywordList, `["customer-1","vip"]`),
+		"OrderTotal":    indexedPayload(SearchAttributeDouble, `12.5`),
+		"OrderItems":    indexedPayload(SearchAttributeInt, `3`),
+		"UntouchedFlag": indexedPayload(SearchAttributeBool, `true`),
+	}}
+	email, err := converter.GetDefaultDataConverter().ToPayload("someone@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started.Memo = &common.Memo{Fields: map[string]*common.Payload{"email": email}}
+
+	redactor, err := NewRedactor(&RedactionRules{
+		Fields: []RedactionRule{{Match: "workflow_execution_started_event_attributes.input", Action: RedactHash}},
+		SearchAttributes: []RedactionRule{
+			{Match: "CustomerID", Action: RedactHash},
+			{Match: "CustomerTags", Action: RedactHash},
+			{Match: "OrderTotal", Action: RedactHash},
+			{Match: "OrderItems", Action: RedactScrub},
+		},
+		MemoKeys: []RedactionRule{{Match: "email", Action: RedactScrub}},
+	})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	redacted := redactor.Redact(workflow)
+
+	if problems := VerifyWorkflow(redacted); len(problems) > 0 {
+		t.Errorf("redacted workflow has problems: %v", problems)
+	}
+	if got := FinalSearchAttributes(workflow).GetIndexedFields()["CustomerID"].GetData(); string(got) != `"customer-1"` {
+		t.Errorf("Redact modified the original workflow, CustomerID = %s", got)
+	}
+
+	searchAttributes := FinalSearchAttributes(redacted)
+	for name, payload := range searchAttributes.GetIndexedFields() {
+		if SearchAttributeType(payload) == "" {
+			t.Errorf("search attribute %s lost its type metadata", name)
+		}
+	}
+	decoded, err := DecodeSearchAttributes(searchAttributes)
+	if err != nil {
+		t.Fatalf("DecodeSearchAttributes: %v", err)
+	}
+
+	customerID, ok := decoded["CustomerID"].(string)
+	if !ok || !strings.HasPrefix(customerID, "sha256:") {
+		t.Errorf("CustomerID = %#v, want a hash", decoded["CustomerID"])
+	}
+	tags, ok := decoded["CustomerTags"].([]string)
+	if !ok || len(tags) != 2 || tags[0] != customerID || tags[1] == "vip" {
+		t.Errorf("CustomerTags = %#v, want each keyword hashed like CustomerID", decoded["CustomerTags"])
+	}
+	for _, name := range []string{"OrderTotal", "OrderItems"} {
+		if value, ok := decoded[name]; ok {
+			t.Errorf("%s = %#v, want it scrubbed", name, value)
+		}
+	}
+	if decoded["UntouchedFlag"] != true {
+		t.Errorf("UntouchedFlag = %#v, want true", decoded["UntouchedFlag"])
+	}
+	if !BySearchAttribute("CustomerTags", customerID)(redacted) {
+		t.Errorf("BySearchAttribute does not match the hashed keyword")
+	}
+
+	memo, err := DecodeMemo(redacted.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes().GetMemo())
+	if err != nil {
+		t.Fatalf("DecodeMemo: %v", err)
+	}
+	if memo["email"] != nil {
+		t.Errorf("memo email = %#v, want it scrubbed", memo["email"])
+	}
+
+	var input string
+	payload := redacted.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes().GetInput().GetPayloads()[0]
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &input); err != nil || !strings.HasPrefix(input, "sha256:") {
+		t.Errorf("hashed input = %q (%v), want a hash that decodes as a string", input, err)
+	}
+}
+
+func TestRedactHashKey(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	rules := &RedactionRules{Fields: []RedactionRule{{Match: "**.input", Action: RedactHash}}}
+	plain, err := NewRedactor(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules.HashKey = "secret"
+	keyed, err := NewRedactor(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := func(redactor *Redactor) string {
+		events := redactor.Redact(workflow).GetHistory().GetEvents()
+		return string(events[0].GetWorkflowExecutionStartedEventAttributes().GetInput().GetPayloads()[0].GetData())
+	}
+	if a, b := input(plain), input(plain); a != b {
+		t.Errorf("hashing is not deterministic: %s != %s", a, b)
+	}
+	if got := input(keyed); !strings.HasPrefix(got, `"hmac-sha256:`) || got == input(plain) {
+		t.Errorf("keyed hash = %s, want a different HMAC hash", got)
+	}
+}
+
+func TestRedactHashScrubsScalars(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	redactor, err := NewRedactor(&RedactionRules{Fields: []RedactionRule{
+		{Match: "**.attempt", Action: RedactHash},
+		{Match: "activity_task_scheduled_event_attributes.retry_policy", Action: RedactHash},
+	}})
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	redacted := redactor.Redact(workflow)
+
+	var started, scheduled int
+	for _, event := range redacted.GetHistory().GetEvents() {
+		if attributes := event.GetActivityTaskStartedEventAttributes(); attributes != nil {
+			started++
+			if attributes.GetAttempt() != 0 {
+				t.Errorf("event %d attempt = %d, want it cleared", event.GetEventId(), attributes.GetAttempt())
+			}
+		}
+		if policy := event.GetActivityTaskScheduledEventAttributes().GetRetryPolicy(); policy != nil {
+			scheduled++
+			if policy.GetMaximumAttempts() != 0 || policy.GetBackoffCoefficient() != 0 || policy.GetInitialInterval().GetSeconds() != 0 {
+				t.Errorf("event %d retry policy = %v, want its numbers cleared", event.GetEventId(), policy)
+			}
+		}
+	}
+	if started == 0 || scheduled == 0 {
+		t.Fatalf("generated workflow has %d started activities and %d retry policies", started, scheduled)
+	}
+	if workflow.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes().GetAttempt() == 0 {
+		t.Error("Redact modified the original workflow")
+	}
+}
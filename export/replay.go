@@ -0,0 +1,56 @@
+package export
+
+import (
+	"fmt"
+
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/worker"
+	sdkworkflow "go.temporal.io/sdk/workflow"
+)
+
+// ReplayResult is the outcome of replaying a single exported workflow execution
+type ReplayResult struct {
+	WorkflowID   string
+	RunID        string
+	WorkflowType string
+	// Err is nil when the history replayed successfully. Otherwise it holds the replay failure, typically a
+	// nondeterminism error caused by a workflow code change that is incompatible with the recorded history
+	Err error
+}
+
+// ReplayAll replays the history of every workflow execution through replayer, which must have the workflows under test
+// registered, and returns one result per execution in the same order. The logger is optional, see
+// worker.WorkflowReplayer.ReplayWorkflowHistory
+func ReplayAll(replayer worker.WorkflowReplayer, logger log.Logger, workflows []*export.WorkflowExecution) []*ReplayResult {
+	results := make([]*ReplayResult, 0, len(workflows))
+	for _, workflow := range workflows {
+		results = append(results, Replay(replayer, logger, workflow))
+	}
+	return results
+}
+
+// Replay replays the history of a single exported workflow execution through replayer
+func Replay(replayer worker.WorkflowReplayer, logger log.Logger, workflow *export.WorkflowExecution) (result *ReplayResult) {
+	info, err := GetExportedWorkflowExecutionInfo(workflow)
+	if err != nil {
+		return &ReplayResult{Err: err}
+	}
+
+	result = &ReplayResult{
+		WorkflowID:   info.WorkflowID,
+		RunID:        info.RunID,
+		WorkflowType: info.WorkflowType,
+	}
+	// The replayer panics instead of returning an error for some malformed histories, for example when the start event
+	// has no task queue
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("replay panicked, the history may be malformed: %v", r)
+		}
+	}()
+	result.Err = replayer.ReplayWorkflowHistoryWithOptions(logger, workflow.GetHistory(), worker.ReplayWorkflowHistoryOptions{
+		OriginalExecution: sdkworkflow.Execution{ID: info.WorkflowID, RunID: info.RunID},
+	})
+	return result
+}
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/worker"
+	sdkworkflow "go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// greetHistory returns the history of a workflow of the given type that completed in its first workflow task without
+// scheduling any work
+func greetHistory(workflowType string) *export.WorkflowExecution {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	event := func(eventType enumspb.EventType, attributes any) *historypb.HistoryEvent {
+		e := &historypb.HistoryEvent{EventType: eventType, EventTime: timestamppb.New(start)}
+		switch a := attributes.(type) {
+		case *historypb.WorkflowExecutionStartedEventAttributes:
+			e.Attributes = &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: a}
+		case *historypb.WorkflowTaskScheduledEventAttributes:
+			e.Attributes = &historypb.HistoryEvent_WorkflowTaskScheduledEventAttributes{WorkflowTaskScheduledEventAttributes: a}
+		case *historypb.WorkflowTaskStartedEventAttributes:
+			e.Attributes = &historypb.HistoryEvent_WorkflowTaskStartedEventAttributes{WorkflowTaskStartedEventAttributes: a}
+		case *historypb.WorkflowTaskCompletedEventAttributes:
+			e.Attributes = &historypb.HistoryEvent_WorkflowTaskCompletedEventAttributes{WorkflowTaskCompletedEventAttributes: a}
+		case *historypb.WorkflowExecutionCompletedEventAttributes:
+			e.Attributes = &historypb.HistoryEvent_WorkflowExecutionCompletedEventAttributes{WorkflowExecutionCompletedEventAttributes: a}
+		}
+		return e
+	}
+	taskQueue := &taskqueuepb.TaskQueue{Name: "greetings", Kind: enumspb.TASK_QUEUE_KIND_NORMAL}
+	events := []*historypb.HistoryEvent{
+		event(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED, &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType:             &common.WorkflowType{Name: workflowType},
+			TaskQueue:                taskQueue,
+			WorkflowTaskTimeout:      durationpb.New(10 * time.Second),
+			OriginalExecutionRunId:   "greet-run",
+			FirstExecutionRunId:      "greet-run",
+			WorkflowId:               "greet",
+			Attempt:                  1,
+			WorkflowExecutionTimeout: durationpb.New(0),
+		}),
+		event(enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED, &historypb.WorkflowTaskScheduledEventAttributes{TaskQueue: taskQueue, Attempt: 1}),
+		event(enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED, &historypb.WorkflowTaskStartedEventAttributes{ScheduledEventId: 2}),
+		event(enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED, &historypb.WorkflowTaskCompletedEventAttributes{ScheduledEventId: 2, StartedEventId: 3}),
+		event(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED, &historypb.WorkflowExecutionCompletedEventAttributes{WorkflowTaskCompletedEventId: 4}),
+	}
+	for i, e := range events {
+		e.EventId = int64(i + 1)
+	}
+	return &export.WorkflowExecution{History: &historypb.History{Events: events}}
+}
+
+func greetWorkflow(ctx sdkworkflow.Context) error {
+	return nil
+}
+
+// greetWithTimerWorkflow is greetWorkflow after an incompatible change
+func greetWithTimerWorkflow(ctx sdkworkflow.Context) error {
+	return sdkworkflow.Sleep(ctx, time.Minute)
+}
+
+// panickingReplayer is a replayer whose replay always panics
+type panickingReplayer struct {
+	worker.WorkflowReplayer
+}
+
+func (panickingReplayer) ReplayWorkflowHistoryWithOptions(log.Logger, *historypb.History, worker.ReplayWorkflowHistoryOptions) error {
+	panic("no workflow context")
+}
+
+func TestReplayAll(t *testing.T) {
+	replayer := worker.NewWorkflowReplayer()
+	replayer.RegisterWorkflowWithOptions(greetWorkflow, sdkworkflow.RegisterOptions{Name: "Greet"})
+	replayer.RegisterWorkflowWithOptions(greetWithTimerWorkflow, sdkworkflow.RegisterOptions{Name: "GreetChanged"})
+
+	logger := log.NewStructuredLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	results := ReplayAll(replayer, logger, []*export.WorkflowExecution{greetHistory("Greet"), greetHistory("GreetChanged"), greetHistory("Unregistered")})
+	if len(results) != 3 {
+		t.Fatalf("ReplayAll returned %d results, want 3", len(results))
+	}
+	if results[0].Err != nil {
+		t.Errorf("replaying a matching history failed: %v", results[0].Err)
+	}
+	if results[0].WorkflowID != "greet" || results[0].RunID != "greet-run" || results[0].WorkflowType != "Greet" {
+		t.Errorf("result = %+v, want the greet execution", results[0])
+	}
+	if err := results[1].Err; err == nil || !strings.Contains(err.Error(), "nondeterministic workflow") {
+		t.Errorf("replaying a mismatched history returned %v, want a nondeterminism error", err)
+	}
+	if err := results[2].Err; err == nil || !strings.Contains(err.Error(), "unable to find workflow type") {
+		t.Errorf("replaying an unregistered workflow type returned %v, want an error", err)
+	}
+}
+
+func TestReplayRecoversPanics(t *testing.T) {
+	result := Replay(panickingReplayer{}, nil, greetHistory("Greet"))
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "replay panicked, the history may be malformed: no workflow context") {
+		t.Errorf("Replay() error = %v, want the recovered panic", result.Err)
+	}
+
+	// The SDK's replayer panics on a start event without a task queue
+	replayer := worker.NewWorkflowReplayer()
+	replayer.RegisterWorkflowWithOptions(greetWorkflow, sdkworkflow.RegisterOptions{Name: "Greet"})
+	malformed := greetHistory("Greet")
+	malformed.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes().TaskQueue = nil
+	if result := Replay(replayer, nil, malformed); result.Err == nil || !strings.Contains(result.Err.Error(), "replay panicked") {
+		t.Errorf("Replay() of a malformed history returned %v, want the recovered panic", result.Err)
+	}
+	if result.RunID != "greet-run" {
+		t.Errorf("Replay() run ID = %q, want greet-run", result.RunID)
+	}
+
+	if result := Replay(panickingReplayer{}, nil, &export.WorkflowExecution{}); result.Err == nil {
+		t.Error("Replay() of an execution without history succeeded")
+	}
+}
@@ -0,0 +1,206 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// searchAttributeTypeMetadata is the payload metadata key holding the type of an indexed search attribute payload
+const searchAttributeTypeMetadata = "type"
+
+// Search attribute types as recorded in the type metadata of indexed payloads
+const (
+	SearchAttributeKeyword     = "Keyword"
+	SearchAttributeText        = "Text"
+	SearchAttributeKeywordList = "KeywordList"
+	SearchAttributeInt         = "Int"
+	SearchAttributeDouble      = "Double"
+	SearchAttributeBool        = "Bool"
+	SearchAttributeDatetime    = "Datetime"
+)
+
+// SearchAttributeType returns the type recorded in the metadata of an indexed search attribute payload, such as
+// "Keyword" or "Int", or an empty string if the payload has no type metadata
+func SearchAttributeType(payload *common.Payload) string {
+	return string(payload.GetMetadata()[searchAttributeTypeMetadata])
+}
+
+// DecodeSearchAttribute decodes an indexed search attribute payload according to its type metadata. Keyword and Text
+// attributes decode to a string, KeywordList to a []string, Int to an int64, Double to a float64, Bool to a bool and
+// Datetime to a time.Time. Payloads without type metadata are decoded as plain JSON. A null payload, which an upsert
+// uses to unset an attribute, decodes to nil
+func DecodeSearchAttribute(payload *common.Payload) (any, error) {
+	if isNullPayload(payload) {
+		return nil, nil
+	}
+	if encoding := string(payload.GetMetadata()[converter.MetadataEncoding]); encoding != converter.MetadataEncodingJSON {
+		return nil, fmt.Errorf("unsupported search attribute encoding %q", encoding)
+	}
+
+	data := payload.GetData()
+	switch SearchAttributeType(payload) {
+	case SearchAttributeKeyword, SearchAttributeText:
+		return decodeIndexedValue[string](data)
+	case SearchAttributeKeywordList:
+		var values []string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	case SearchAttributeInt:
+		return decodeIndexedValue[int64](data)
+	case SearchAttributeDouble:
+		return decodeIndexedValue[float64](data)
+	case SearchAttributeBool:
+		return decodeIndexedValue[bool](data)
+	case SearchAttributeDatetime:
+		return decodeIndexedValue[time.Time](data)
+	default:
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// decodeIndexedValue decodes a single value of type T. Older servers and SDKs record every search attribute as a JSON
+// array, a single element array decodes to the element and longer arrays decode to a []T
+func decodeIndexedValue[T any](data []byte) (any, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var values []T
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+		if len(values) == 1 {
+			return values[0], nil
+		}
+		return values, nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// DecodeSearchAttributes decodes every search attribute with DecodeSearchAttribute. Unset attributes are left out
+func DecodeSearchAttributes(searchAttributes *common.SearchAttributes) (map[string]any, error) {
+	decoded := make(map[string]any, len(searchAttributes.GetIndexedFields()))
+	for name, payload := range searchAttributes.GetIndexedFields() {
+		value, err := DecodeSearchAttribute(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode search attribute %s: %w", name, err)
+		}
+		if value != nil {
+			decoded[name] = value
+		}
+	}
+	return decoded, nil
+}
+
+// DecodeMemo decodes every memo value with the SDK's default data converter, so JSON values decode to maps, slices,
+// strings, float64s and bools. Memo payloads encrypted by a codec must be decoded with a PayloadRenderer first
+func DecodeMemo(memo *common.Memo) (map[string]any, error) {
+	dataConverter := converter.GetDefaultDataConverter()
+	decoded := make(map[string]any, len(memo.GetFields()))
+	for key, payload := range memo.GetFields() {
+		var value any
+		if err := dataConverter.FromPayload(payload, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode memo %s: %w", key, err)
+		}
+		decoded[key] = value
+	}
+	return decoded, nil
+}
+
+// FinalSearchAttributes returns the search attributes of an execution at the end of its history, those recorded on the
+// start event with the attributes of every UpsertWorkflowSearchAttributes event applied in order. Attributes unset by
+// an upsert are removed
+func FinalSearchAttributes(workflow *export.WorkflowExecution) *common.SearchAttributes {
+	fields := map[string]*common.Payload{}
+	for _, event := range workflow.GetHistory().GetEvents() {
+		var upserted *common.SearchAttributes
+		if attributes := event.GetWorkflowExecutionStartedEventAttributes(); attributes != nil {
+			upserted = attributes.GetSearchAttributes()
+		} else if attributes := event.GetUpsertWorkflowSearchAttributesEventAttributes(); attributes != nil {
+			upserted = attributes.GetSearchAttributes()
+		}
+		mergePayloads(fields, upserted.GetIndexedFields())
+	}
+	return &common.SearchAttributes{IndexedFields: fields}
+}
+
+// FinalMemo returns the memo of an execution at the end of its history, the memo recorded on the start event with the
+// memo of every WorkflowPropertiesModified event applied in order
+func FinalMemo(workflow *export.WorkflowExecution) *common.Memo {
+	fields := map[string]*common.Payload{}
+	for _, event := range workflow.GetHistory().GetEvents() {
+		var upserted *common.Memo
+		if attributes := event.GetWorkflowExecutionStartedEventAttributes(); attributes != nil {
+			upserted = attributes.GetMemo()
+		} else if attributes := event.GetWorkflowPropertiesModifiedEventAttributes(); attributes != nil {
+			upserted = attributes.GetUpsertedMemo()
+		}
+		mergePayloads(fields, upserted.GetFields())
+	}
+	return &common.Memo{Fields: fields}
+}
+
+// mergePayloads applies upserted payloads to fields, removing the keys whose upserted payload is null
+func mergePayloads(fields, upserted map[string]*common.Payload) {
+	for _, key := range slices.Sorted(maps.Keys(upserted)) {
+		if payload := upserted[key]; isNullPayload(payload) {
+			delete(fields, key)
+		} else {
+			fields[key] = payload
+		}
+	}
+}
+
+func isNullPayload(payload *common.Payload) bool {
+	data := bytes.TrimSpace(payload.GetData())
+	return len(data) == 0 || string(data) == "null" ||
+		string(payload.GetMetadata()[converter.MetadataEncoding]) == converter.MetadataEncodingNil
+}
+
+// FormatSearchAttributes renders search attributes as a sorted, comma separated list of Name=value pairs with the
+// values decoded and rendered as JSON, e.g. `CustomIntField=5, CustomKeywordField="foo"`
+func FormatSearchAttributes(searchAttributes *common.SearchAttributes) string {
+	return formatPayloads(searchAttributes.GetIndexedFields(), DecodeSearchAttribute)
+}
+
+// FormatMemo renders a memo as a sorted, comma separated list of key=value pairs with the values decoded and rendered
+// as JSON
+func FormatMemo(memo *common.Memo) string {
+	dataConverter := converter.GetDefaultDataConverter()
+	return formatPayloads(memo.GetFields(), func(payload *common.Payload) (any, error) {
+		var value any
+		err := dataConverter.FromPayload(payload, &value)
+		return value, err
+	})
+}
+
+// formatPayloads renders payloads decoded by decode, payloads that cannot be decoded are rendered as their encoding
+func formatPayloads(payloads map[string]*common.Payload, decode func(*common.Payload) (any, error)) string {
+	pairs := make([]string, 0, len(payloads))
+	for _, key := range slices.Sorted(maps.Keys(payloads)) {
+		rendered := fmt.Sprintf("<%s>", payloads[key].GetMetadata()[converter.MetadataEncoding])
+		if value, err := decode(payloads[key]); err == nil {
+			if b, err := json.Marshal(value); err == nil {
+				rendered = string(b)
+			}
+		}
+		pairs = append(pairs, key+"="+rendered)
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestDecodeSearchAttribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload *common.Payload
+		want    any
+	}{
+		{name: "keyword", payload: indexedPayload(SearchAttributeKeyword, `"customer-1"`), want: "customer-1"},
+		{name: "text", payload: indexedPayload(SearchAttributeText, `"a long description"`), want: "a long description"},
+		{name: "keyword list", payload: indexedPayload(SearchAttributeKeywordList, `["a","b"]`), want: []string{"a", "b"}},
+		{name: "int", payload: indexedPayload(SearchAttributeInt, `42`), want: int64(42)},
+		{name: "double", payload: indexedPayload(SearchAttributeDouble, `1.5`), want: 1.5},
+		{name: "bool", payload: indexedPayload(SearchAttributeBool, `true`), want: true},
+		{name: "datetime", payload: indexedPayload(SearchAttributeDatetime, `"2024-06-01T12:00:00Z"`), want: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "single element array", payload: indexedPayload(SearchAttributeInt, `[7]`), want: int64(7)},
+		{name: "array", payload: indexedPayload(SearchAttributeInt, `[7,8]`), want: []int64{7, 8}},
+		{name: "untyped", payload: indexedPayload("", `{"a":1}`), want: map[string]any{"a": 1.0}},
+		{name: "null", payload: indexedPayload(SearchAttributeKeyword, `null`), want: nil},
+		{name: "nil encoding", payload: &common.Payload{Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingNil)}}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeSearchAttribute(tt.payload)
+			if err != nil {
+				t.Fatalf("DecodeSearchAttribute: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeSearchAttribute() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	binary := &common.Payload{Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingBinary)}, Data: []byte{1}}
+	if _, err := DecodeSearchAttribute(binary); err == nil {
+		t.Error("DecodeSearchAttribute accepted a binary payload")
+	}
+}
+
+// upsertSearchAttributes returns a copy of workflow with an UpsertWorkflowSearchAttributes event for fields inserted
+// before its close event
+func upsertSearchAttributes(workflow *export.WorkflowExecution, fields map[string]*common.Payload) *export.WorkflowExecution {
+	events := workflow.GetHistory().GetEvents()
+	last := len(events) - 1
+	upsert := &historypb.HistoryEvent{
+		EventTime: events[last].GetEventTime(),
+		EventType: enumspb.EVENT_TYPE_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES,
+		Attributes: &historypb.HistoryEvent_UpsertWorkflowSearchAttributesEventAttributes{
+			UpsertWorkflowSearchAttributesEventAttributes: &historypb.UpsertWorkflowSearchAttributesEventAttributes{
+				SearchAttributes: &common.SearchAttributes{IndexedFields: fields},
+			},
+		},
+	}
+	events = append(events[:last:last], upsert, events[last])
+	for i, event := range events {
+		event.EventId = int64(i + 1)
+	}
+	return &export.WorkflowExecution{History: &historypb.History{Events: events}}
+}
+
+func TestFinalSearchAttributes(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	started := workflow.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes()
+	started.SearchAttributes = &common.SearchAttributes{IndexedFields: map[string]*common.Payload{
+		"CustomerID": indexedPayload(SearchAttributeKeyword, `"customer-1"`),
+		"Priority":   indexedPayload(SearchAttributeInt, `1`),
+		"Region":     indexedPayload(SearchAttributeKeyword, `"eu"`),
+	}}
+	workflow = upsertSearchAttributes(workflow, map[string]*common.Payload{
+		"Priority": indexedPayload(SearchAttributeInt, `2`),
+		"Region":   indexedPayload(SearchAttributeKeyword, `null`),
+		"Tags":     indexedPayload(SearchAttributeKeywordList, `["vip","new"]`),
+	})
+
+	decoded, err := DecodeSearchAttributes(FinalSearchAttributes(workflow))
+	if err != nil {
+		t.Fatalf("DecodeSearchAttributes: %v", err)
+	}
+	want := map[string]any{"CustomerID": "customer-1", "Priority": int64(2), "Tags": []string{"vip", "new"}}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("final search attributes = %#v, want %#v", decoded, want)
+	}
+	if got, want := FormatSearchAttributes(FinalSearchAttributes(workflow)), `CustomerID="customer-1", Priority=2, Tags=["vip","new"]`; got != want {
+		t.Errorf("FormatSearchAttributes() = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name, value string
+		want        bool
+	}{
+		{name: "CustomerID", value: "customer-1", want: true},
+		{name: "CustomerID", value: "customer", want: false},
+		{name: "CustomerID", value: "", want: true},
+		{name: "Priority", value: "2", want: true},
+		{name: "Priority", value: "1", want: false},
+		{name: "Priority", value: "high", want: false},
+		{name: "Tags", value: "vip", want: true},
+		{name: "Tags", value: "old", want: false},
+		{name: "Region", value: "", want: false},
+		{name: "Missing", value: "", want: false},
+	}
+	for _, tt := range tests {
+		if got := BySearchAttribute(tt.name, tt.value)(workflow); got != tt.want {
+			t.Errorf("BySearchAttribute(%q, %q) = %t, want %t", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestBySearchAttributeTypes(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	started := workflow.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes()
+	started.SearchAttributes = &common.SearchAttributes{IndexedFields: map[string]*common.Payload{
+		"Description": indexedPayload(SearchAttributeText, `"express order for a new customer"`),
+		"Total":       indexedPayload(SearchAttributeDouble, `12.5`),
+		"Paid":        indexedPayload(SearchAttributeBool, `true`),
+		"DueAt":       indexedPayload(SearchAttributeDatetime, `"2024-06-01T12:00:00Z"`),
+		"LegacyIDs":   indexedPayload(SearchAttributeInt, `[3,4]`),
+	}}
+
+	tests := []struct {
+		name, value string
+		want        bool
+	}{
+		{name: "Description", value: "new customer", want: true},
+		{name: "Description", value: "returning customer", want: false},
+		{name: "Total", value: "12.5", want: true},
+		{name: "Total", value: "12", want: false},
+		{name: "Paid", value: "true", want: true},
+		{name: "Paid", value: "false", want: false},
+		{name: "DueAt", value: "2024-06-01T14:00:00+02:00", want: true},
+		{name: "DueAt", value: "2024-06-01", want: false},
+		{name: "LegacyIDs", value: "4", want: true},
+		{name: "LegacyIDs", value: "5", want: false},
+	}
+	for _, tt := range tests {
+		if got := BySearchAttribute(tt.name, tt.value)(workflow); got != tt.want {
+			t.Errorf("BySearchAttribute(%q, %q) = %t, want %t", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFinalMemo(t *testing.T) {
+	dataConverter := converter.GetDefaultDataConverter()
+	toPayload := func(value any) *common.Payload {
+		payload, err := dataConverter.ToPayload(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return payload
+	}
+
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	events := workflow.GetHistory().GetEvents()
+	events[0].GetWorkflowExecutionStartedEventAttributes().Memo = &common.Memo{Fields: map[string]*common.Payload{
+		"owner": toPayload("team-a"),
+		"notes": toPayload("first run"),
+	}}
+	last := len(events) - 1
+	modified := &historypb.HistoryEvent{
+		EventTime: events[last].GetEventTime(),
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_PROPERTIES_MODIFIED,
+		Attributes: &historypb.HistoryEvent_WorkflowPropertiesModifiedEventAttributes{
+			WorkflowPropertiesModifiedEventAttributes: &historypb.WorkflowPropertiesModifiedEventAttributes{
+				UpsertedMemo: &common.Memo{Fields: map[string]*common.Payload{
+					"owner": toPayload("team-b"),
+					"notes": toPayload(nil),
+				}},
+			},
+		},
+	}
+	workflow.History.Events = append(events[:last:last], modified, events[last])
+
+	decoded, err := DecodeMemo(FinalMemo(workflow))
+	if err != nil {
+		t.Fatalf("DecodeMemo: %v", err)
+	}
+	if want := map[string]any{"owner": "team-b"}; !reflect.DeepEqual(decoded, want) {
+		t.Errorf("final memo = %#v, want %#v", decoded, want)
+	}
+}
@@ -0,0 +1,256 @@
+// Package sqlite loads exported workflow executions into a SQLite database so their histories can be queried with SQL
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	exportpb "go.temporal.io/api/export/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	_ "modernc.org/sqlite"
+
+	"github.com/temporalio/cloud-samples-go/export"
+)
+
+// schema creates the tables and indexes. Every table is keyed by the execution's workflow ID and run ID, which is what
+// makes loading the same executions twice replace the earlier rows instead of duplicating them
+const schema = `
+CREATE TABLE IF NOT EXISTS executions (
+	workflow_id           TEXT NOT NULL,
+	run_id                TEXT NOT NULL,
+	workflow_type         TEXT NOT NULL,
+	task_queue            TEXT NOT NULL,
+	status                TEXT NOT NULL,
+	start_time            TEXT NOT NULL,
+	close_time            TEXT,
+	event_count           INTEGER NOT NULL,
+	original_run_id       TEXT NOT NULL,
+	first_run_id          TEXT NOT NULL,
+	continued_from_run_id TEXT,
+	new_run_id            TEXT,
+	parent_workflow_id    TEXT,
+	parent_run_id         TEXT,
+	PRIMARY KEY (workflow_id, run_id)
+);
+CREATE INDEX IF NOT EXISTS executions_workflow_type ON executions (workflow_type);
+CREATE INDEX IF NOT EXISTS executions_status ON executions (status);
+CREATE INDEX IF NOT EXISTS executions_start_time ON executions (start_time);
+CREATE INDEX IF NOT EXISTS executions_close_time ON executions (close_time);
+
+CREATE TABLE IF NOT EXISTS events (
+	workflow_id TEXT NOT NULL,
+	run_id      TEXT NOT NULL,
+	event_id    INTEGER NOT NULL,
+	event_time  TEXT NOT NULL,
+	event_type  TEXT NOT NULL,
+	event_json  TEXT NOT NULL,
+	PRIMARY KEY (workflow_id, run_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS events_event_type ON events (event_type);
+
+CREATE TABLE IF NOT EXISTS activities (
+	workflow_id        TEXT NOT NULL,
+	run_id             TEXT NOT NULL,
+	scheduled_event_id INTEGER NOT NULL,
+	activity_id        TEXT NOT NULL,
+	activity_type      TEXT NOT NULL,
+	task_queue         TEXT NOT NULL,
+	status             TEXT NOT NULL,
+	attempt            INTEGER,
+	scheduled_time     TEXT NOT NULL,
+	started_time       TEXT,
+	close_time         TEXT,
+	failure_message    TEXT,
+	PRIMARY KEY (workflow_id, run_id, scheduled_event_id)
+);
+CREATE INDEX IF NOT EXISTS activities_activity_type ON activities (activity_type);
+CREATE INDEX IF NOT EXISTS activities_status ON activities (status);
+
+CREATE TABLE IF NOT EXISTS signals (
+	workflow_id TEXT NOT NULL,
+	run_id      TEXT NOT NULL,
+	event_id    INTEGER NOT NULL,
+	event_time  TEXT NOT NULL,
+	signal_name TEXT NOT NULL,
+	identity    TEXT NOT NULL,
+	PRIMARY KEY (workflow_id, run_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS signals_signal_name ON signals (signal_name);
+
+CREATE TABLE IF NOT EXISTS failures (
+	workflow_id      TEXT NOT NULL,
+	run_id           TEXT NOT NULL,
+	event_id         INTEGER NOT NULL,
+	event_time       TEXT NOT NULL,
+	event_type       TEXT NOT NULL,
+	related_event_id INTEGER,
+	failure_type     TEXT NOT NULL,
+	message          TEXT NOT NULL,
+	stack_trace      TEXT NOT NULL,
+	PRIMARY KEY (workflow_id, run_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS failures_event_type ON failures (event_type);
+CREATE INDEX IF NOT EXISTS failures_failure_type ON failures (failure_type);
+`
+
+// tables are the tables holding rows of a single execution, cleared before the execution is loaded again
+var tables = []string{"executions", "events", "activities", "signals", "failures"}
+
+// Loader writes exported workflow executions into a SQLite database. The events table stores each history event whole,
+// as protojson in its event_json column
+type Loader struct {
+	db *sql.DB
+}
+
+// Open opens or creates the SQLite database at path and creates any missing tables and indexes
+func Open(path string) (*Loader, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	return &Loader{db: db}, nil
+}
+
+// Close closes the database
+func (l *Loader) Close() error {
+	return l.db.Close()
+}
+
+// Load writes the workflow executions to the database in a single transaction. Executions that were loaded before are
+// replaced, so loading the same export file twice leaves the database unchanged
+func (l *Loader) Load(ctx context.Context, workflows []*exportpb.WorkflowExecution) error {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, workflow := range workflows {
+		if err := loadWorkflow(ctx, tx, workflow); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func loadWorkflow(ctx context.Context, tx *sql.Tx, workflow *exportpb.WorkflowExecution) error {
+	info, err := export.GetExportedWorkflowExecutionInfo(workflow)
+	if err != nil {
+		return fmt.Errorf("failed to load workflow: %w", err)
+	}
+	id, runID := info.WorkflowID, info.RunID
+
+	exec := func(query string, args ...any) error {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to load workflow %s, run %s: %w", id, runID, err)
+		}
+		return nil
+	}
+
+	for _, table := range tables {
+		if err := exec("DELETE FROM "+table+" WHERE workflow_id = ? AND run_id = ?", id, runID); err != nil {
+			return err
+		}
+	}
+
+	err = exec(`INSERT INTO executions (workflow_id, run_id, workflow_type, task_queue, status, start_time, close_time,
+		event_count, original_run_id, first_run_id, continued_from_run_id, new_run_id, parent_workflow_id, parent_run_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, runID, info.WorkflowType, info.TaskQueue, info.Status.String(), formatTime(info.StartTime),
+		nullTime(info.CloseTime), info.EventCount, info.OriginalRunID, info.FirstRunID,
+		nullString(info.ContinuedFromRunID), nullString(info.NewRunID),
+		nullString(info.ParentExecution.GetWorkflowId()), nullString(info.ParentExecution.GetRunId()))
+	if err != nil {
+		return err
+	}
+
+	for _, event := range workflow.GetHistory().GetEvents() {
+		eventJSON, err := protojson.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event %d of workflow %s, run %s: %w", event.GetEventId(), id, runID, err)
+		}
+		eventTime := event.GetEventTime().AsTime()
+		err = exec("INSERT INTO events (workflow_id, run_id, event_id, event_time, event_type, event_json) VALUES (?, ?, ?, ?, ?, ?)",
+			id, runID, event.GetEventId(), formatTime(eventTime), event.GetEventType().String(), string(eventJSON))
+		if err != nil {
+			return err
+		}
+
+		if f, relatedEventID := export.EventFailure(event); f != nil {
+			err = exec(`INSERT INTO failures (workflow_id, run_id, event_id, event_time, event_type, related_event_id,
+				failure_type, message, stack_trace) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				id, runID, event.GetEventId(), formatTime(eventTime), event.GetEventType().String(),
+				nullInt(relatedEventID), export.FailureType(f), f.GetMessage(), f.GetStackTrace())
+			if err != nil {
+				return err
+			}
+		}
+
+		if event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED {
+			a := event.GetWorkflowExecutionSignaledEventAttributes()
+			err = exec("INSERT INTO signals (workflow_id, run_id, event_id, event_time, signal_name, identity) VALUES (?, ?, ?, ?, ?, ?)",
+				id, runID, event.GetEventId(), formatTime(eventTime), a.GetSignalName(), a.GetIdentity())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, run := range export.ActivityRuns(workflow) {
+		// Only failed and timed out activities have a failure message, not completed activities whose earlier attempts
+		// failed
+		var failureMessage any
+		if (run.Status == export.ActivityFailed || run.Status == export.ActivityTimedOut) && run.Failure != nil {
+			failureMessage = run.Failure.GetMessage()
+		}
+		err = exec(`INSERT INTO activities (workflow_id, run_id, scheduled_event_id, activity_id, activity_type, task_queue,
+			status, attempt, scheduled_time, started_time, close_time, failure_message) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, runID, run.ScheduledEventID, run.ActivityID, run.ActivityType, run.TaskQueue, run.Status.String(),
+			nullInt(int64(run.Attempt)), formatTime(run.ScheduledTime), nullTime(run.StartedTime), nullTime(run.CloseTime),
+			failureMessage)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timeLayout is RFC3339 in UTC with a fixed number of fractional digits, so times sort correctly as text and work with
+// SQLite's date and time functions
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return formatTime(t)
+}
+
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullInt(i int64) any {
+	if i == 0 {
+		return nil
+	}
+	return i
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/temporalio/cloud-samples-go/internal/exporttest"
+)
+
+func TestLoad(t *testing.T) {
+	workflows := exporttest.Workflows(t, 100)
+
+	loader, err := Open(filepath.Join(t.TempDir(), "export.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loader.Close()
+
+	// Loading the same executions twice must not duplicate any rows
+	for range 2 {
+		if err := loader.Load(context.Background(), workflows); err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+	}
+
+	count := func(query string) int {
+		t.Helper()
+		var n int
+		if err := loader.db.QueryRow(query).Scan(&n); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		return n
+	}
+	if n := count("SELECT count(*) FROM executions"); n != len(workflows) {
+		t.Errorf("executions has %d rows, want %d", n, len(workflows))
+	}
+	var events int
+	for _, workflow := range workflows {
+		events += len(workflow.GetHistory().GetEvents())
+	}
+	if n := count("SELECT count(*) FROM events"); n != events {
+		t.Errorf("events has %d rows, want %d", n, events)
+	}
+	if n := count("SELECT count(*) FROM events WHERE json_extract(event_json, '$.eventId') != CAST(event_id AS TEXT)"); n != 0 {
+		t.Errorf("%d events do not store the event as protojson", n)
+	}
+	if n := count("SELECT count(*) FROM activities a LEFT JOIN executions e USING (workflow_id, run_id) WHERE e.workflow_id IS NULL"); n != 0 {
+		t.Errorf("%d activities do not join to an execution", n)
+	}
+	if n := count("SELECT count(*) FROM activities WHERE status = 'Completed' AND attempt > 1"); n == 0 {
+		t.Errorf("no retried activities were loaded")
+	}
+	if n := count("SELECT count(*) FROM activities WHERE status IN ('Completed', 'Canceled') AND failure_message IS NOT NULL"); n != 0 {
+		t.Errorf("%d completed or canceled activities have a failure message", n)
+	}
+	if n := count("SELECT count(*) FROM activities WHERE status IN ('Failed', 'TimedOut') AND failure_message IS NULL"); n != 0 {
+		t.Errorf("%d failed activities have no failure message", n)
+	}
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+// GetWorkflowStatus derives the status of an exported workflow execution from the last event in its history. Executions
+// whose history does not end in a close event are reported as running
+func GetWorkflowStatus(workflow *export.WorkflowExecution) enumspb.WorkflowExecutionStatus {
+	events := workflow.GetHistory().GetEvents()
+	if len(events) == 0 {
+		return enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED
+	}
+
+	return closeEventStatus(events[len(events)-1].GetEventType())
+}
+
+// closeEventStatus returns the status a workflow execution closed with by an event of the given type, or running if the
+// event type does not close the execution
+func closeEventStatus(eventType enumspb.EventType) enumspb.WorkflowExecutionStatus {
+	switch eventType {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		return enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		return enumspb.WORKFLOW_EXECUTION_STATUS_FAILED
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		return enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		return enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		return enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		return enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW
+	default:
+		return enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING
+	}
+}
+
+// ParseWorkflowStatus accepts workflow execution statuses case insensitively with or without separators, so
+// "timed-out", "TimedOut" and "WORKFLOW_EXECUTION_STATUS_TIMED_OUT" are all equivalent
+func ParseWorkflowStatus(s string) (enumspb.WorkflowExecutionStatus, error) {
+	normalize := func(s string) string {
+		s = strings.TrimPrefix(strings.ToUpper(s), "WORKFLOW_EXECUTION_STATUS_")
+		return strings.NewReplacer("_", "", "-", "").Replace(s)
+	}
+	for name, value := range enumspb.WorkflowExecutionStatus_shorthandValue {
+		if normalize(name) == normalize(s) {
+			return enumspb.WorkflowExecutionStatus(value), nil
+		}
+	}
+	return enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, fmt.Errorf("unknown workflow status %q", s)
+}
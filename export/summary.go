@@ -0,0 +1,217 @@
+package export
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+// historyLengthBuckets are the inclusive upper bounds of the history length distribution buckets, the last bucket
+// holds every history longer than the final bound
+var historyLengthBuckets = []int{10, 100, 1000, 10000}
+
+type (
+	// Summary holds aggregate statistics over a set of exported workflow executions
+	Summary struct {
+		WorkflowTypeSummary
+		// Per workflow type statistics keyed by workflow type name
+		WorkflowTypes map[string]*WorkflowTypeSummary
+		// Activity types with at least one failed or timed out activity, ordered by descending failure count
+		ActivityFailures []*ActivityFailureCount
+	}
+
+	// WorkflowTypeSummary holds aggregate statistics over executions of a single workflow type, or all executions when
+	// embedded in a Summary
+	WorkflowTypeSummary struct {
+		Executions int
+		// Number of executions per status, derived from the last event in each history
+		Statuses map[enumspb.WorkflowExecutionStatus]int
+		// Time from the start event to the close event, closed executions only
+		Durations Percentiles[time.Duration]
+		// Number of events per history
+		HistoryLengths Percentiles[int]
+		// Distribution of history lengths
+		HistoryLengthBuckets []HistoryLengthBucket
+	}
+
+	// Percentiles summarizes a distribution of values using the nearest rank method
+	Percentiles[T cmp.Ordered] struct {
+		Count int
+		P50   T
+		P95   T
+		P99   T
+		Max   T
+	}
+
+	// HistoryLengthBucket counts the histories whose length is at most MaxEvents and greater than the previous bucket's
+	// MaxEvents. A MaxEvents of zero marks the unbounded final bucket
+	HistoryLengthBucket struct {
+		MaxEvents int
+		Count     int
+	}
+
+	// ActivityFailureCount counts the activities of a single type that failed or timed out
+	ActivityFailureCount struct {
+		ActivityType string
+		Failed       int
+		TimedOut     int
+	}
+
+	// Summarizer incrementally computes a Summary, which allows summarizing exports that are streamed with a Reader
+	Summarizer struct {
+		all              *typeAccumulator
+		workflowTypes    map[string]*typeAccumulator
+		activityFailures map[string]*ActivityFailureCount
+	}
+
+	typeAccumulator struct {
+		statuses       map[enumspb.WorkflowExecutionStatus]int
+		durations      []time.Duration
+		historyLengths []int
+	}
+)
+
+// Summarize computes aggregate statistics over the given workflow executions
+func Summarize(workflows []*export.WorkflowExecution) *Summary {
+	s := NewSummarizer()
+	for _, workflow := range workflows {
+		s.Add(workflow)
+	}
+	return s.Summary()
+}
+
+// NewSummarizer returns an empty Summarizer
+func NewSummarizer() *Summarizer {
+	return &Summarizer{
+		all:              newTypeAccumulator(),
+		workflowTypes:    map[string]*typeAccumulator{},
+		activityFailures: map[string]*ActivityFailureCount{},
+	}
+}
+
+// Add includes a workflow execution in the summary. Executions without a history are ignored
+func (s *Summarizer) Add(workflow *export.WorkflowExecution) {
+	events := workflow.GetHistory().GetEvents()
+	if len(events) == 0 {
+		return
+	}
+
+	workflowType := events[0].GetWorkflowExecutionStartedEventAttributes().GetWorkflowType().GetName()
+	acc, ok := s.workflowTypes[workflowType]
+	if !ok {
+		acc = newTypeAccumulator()
+		s.workflowTypes[workflowType] = acc
+	}
+
+	status := GetWorkflowStatus(workflow)
+	var duration *time.Duration
+	if status != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		d := events[len(events)-1].GetEventTime().AsTime().Sub(events[0].GetEventTime().AsTime())
+		duration = &d
+	}
+	s.all.add(status, duration, len(events))
+	acc.add(status, duration, len(events))
+
+	activityTypes := map[int64]string{}
+	for _, event := range events {
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			activityTypes[event.GetEventId()] = event.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			s.activityFailure(activityTypes[event.GetActivityTaskFailedEventAttributes().GetScheduledEventId()]).Failed++
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			s.activityFailure(activityTypes[event.GetActivityTaskTimedOutEventAttributes().GetScheduledEventId()]).TimedOut++
+		}
+	}
+}
+
+// Summary returns the statistics over every execution added so far
+func (s *Summarizer) Summary() *Summary {
+	summary := &Summary{
+		WorkflowTypeSummary: s.all.summary(),
+		WorkflowTypes:       make(map[string]*WorkflowTypeSummary, len(s.workflowTypes)),
+	}
+	for workflowType, acc := range s.workflowTypes {
+		typeSummary := acc.summary()
+		summary.WorkflowTypes[workflowType] = &typeSummary
+	}
+
+	for _, failures := range s.activityFailures {
+		f := *failures
+		summary.ActivityFailures = append(summary.ActivityFailures, &f)
+	}
+	slices.SortFunc(summary.ActivityFailures, func(a, b *ActivityFailureCount) int {
+		if c := cmp.Compare(b.Failed+b.TimedOut, a.Failed+a.TimedOut); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ActivityType, b.ActivityType)
+	})
+	return summary
+}
+
+func (s *Summarizer) activityFailure(activityType string) *ActivityFailureCount {
+	failures, ok := s.activityFailures[activityType]
+	if !ok {
+		failures = &ActivityFailureCount{ActivityType: activityType}
+		s.activityFailures[activityType] = failures
+	}
+	return failures
+}
+
+func newTypeAccumulator() *typeAccumulator {
+	return &typeAccumulator{statuses: map[enumspb.WorkflowExecutionStatus]int{}}
+}
+
+func (a *typeAccumulator) add(status enumspb.WorkflowExecutionStatus, duration *time.Duration, historyLength int) {
+	a.statuses[status]++
+	if duration != nil {
+		a.durations = append(a.durations, *duration)
+	}
+	a.historyLengths = append(a.historyLengths, historyLength)
+}
+
+func (a *typeAccumulator) summary() WorkflowTypeSummary {
+	buckets := make([]HistoryLengthBucket, len(historyLengthBuckets)+1)
+	for i, bound := range historyLengthBuckets {
+		buckets[i].MaxEvents = bound
+	}
+	for _, length := range a.historyLengths {
+		i, _ := slices.BinarySearch(historyLengthBuckets, length)
+		buckets[i].Count++
+	}
+
+	statuses := make(map[enumspb.WorkflowExecutionStatus]int, len(a.statuses))
+	for status, count := range a.statuses {
+		statuses[status] = count
+	}
+
+	return WorkflowTypeSummary{
+		Executions:           len(a.historyLengths),
+		Statuses:             statuses,
+		Durations:            computePercentiles(a.durations),
+		HistoryLengths:       computePercentiles(a.historyLengths),
+		HistoryLengthBuckets: buckets,
+	}
+}
+
+func computePercentiles[T cmp.Ordered](values []T) Percentiles[T] {
+	if len(values) == 0 {
+		return Percentiles[T]{}
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := func(p float64) T {
+		return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+	}
+	return Percentiles[T]{
+		Count: len(sorted),
+		P50:   rank(0.50),
+		P95:   rank(0.95),
+		P99:   rank(0.99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
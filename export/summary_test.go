@@ -0,0 +1,192 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// summaryWorkflow returns an execution of the given type with length events that closes with closeType after duration.
+// A closeType that does not close the execution leaves it running
+func summaryWorkflow(workflowType string, closeType enumspb.EventType, duration time.Duration, length int, middle ...*historypb.HistoryEvent) *export.WorkflowExecution {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	events := []*historypb.HistoryEvent{{
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+		EventTime: timestamppb.New(start),
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &common.WorkflowType{Name: workflowType},
+		}},
+	}}
+	events = append(events, middle...)
+	for len(events) < length-1 {
+		events = append(events, &historypb.HistoryEvent{EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED, EventTime: timestamppb.New(start)})
+	}
+	events = append(events, &historypb.HistoryEvent{EventType: closeType, EventTime: timestamppb.New(start.Add(duration))})
+	for i, e := range events {
+		e.EventId = int64(i + 1)
+	}
+	return &export.WorkflowExecution{History: &historypb.History{Events: events}}
+}
+
+// activityEvents returns a scheduled event for an activity of the given type with the given ID, followed by closeEvent
+// when it is not nil
+func activityEvents(activityType string, scheduledEventID int64, closeEvent *historypb.HistoryEvent) []*historypb.HistoryEvent {
+	events := []*historypb.HistoryEvent{{
+		EventId:   scheduledEventID,
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+		Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+			ActivityType: &common.ActivityType{Name: activityType},
+		}},
+	}}
+	if closeEvent != nil {
+		events = append(events, closeEvent)
+	}
+	return events
+}
+
+func activityFailedEvent(scheduledEventID int64) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+		Attributes: &historypb.HistoryEvent_ActivityTaskFailedEventAttributes{ActivityTaskFailedEventAttributes: &historypb.ActivityTaskFailedEventAttributes{
+			ScheduledEventId: scheduledEventID,
+		}},
+	}
+}
+
+func activityTimedOutEvent(scheduledEventID int64) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT,
+		Attributes: &historypb.HistoryEvent_ActivityTaskTimedOutEventAttributes{ActivityTaskTimedOutEventAttributes: &historypb.ActivityTaskTimedOutEventAttributes{
+			ScheduledEventId: scheduledEventID,
+		}},
+	}
+}
+
+// lengthBuckets returns the history length buckets with the given counts, in bucket order
+func lengthBuckets(counts ...int) []HistoryLengthBucket {
+	buckets := make([]HistoryLengthBucket, len(historyLengthBuckets)+1)
+	for i := range buckets {
+		if i < len(historyLengthBuckets) {
+			buckets[i].MaxEvents = historyLengthBuckets[i]
+		}
+		buckets[i].Count = counts[i]
+	}
+	return buckets
+}
+
+func TestSummarize(t *testing.T) {
+	var orderActivities []*historypb.HistoryEvent
+	orderActivities = append(orderActivities, activityEvents("Charge", 2, activityFailedEvent(2))...)
+	orderActivities = append(orderActivities, activityEvents("Charge", 4, activityTimedOutEvent(4))...)
+	orderActivities = append(orderActivities, activityEvents("Ship", 6, activityFailedEvent(6))...)
+	orderActivities = append(orderActivities, activityEvents("Notify", 8, nil)...)
+
+	mixed := []*export.WorkflowExecution{
+		summaryWorkflow("Order", enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED, time.Minute, 5),
+		summaryWorkflow("Order", enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED, 2*time.Minute, 50),
+		summaryWorkflow("Order", enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED, 10*time.Minute, 500, orderActivities...),
+		summaryWorkflow("Order", enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED, time.Hour, 3),
+		summaryWorkflow("Refund", enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT, 30*time.Second, 12000),
+		summaryWorkflow("Refund", enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED, 5*time.Second, 10),
+		{},
+	}
+
+	tests := []struct {
+		name      string
+		workflows []*export.WorkflowExecution
+		want      *Summary
+	}{
+		{
+			name:      "empty",
+			workflows: nil,
+			want: &Summary{
+				WorkflowTypeSummary: WorkflowTypeSummary{
+					Statuses:             map[enumspb.WorkflowExecutionStatus]int{},
+					HistoryLengthBuckets: lengthBuckets(0, 0, 0, 0, 0),
+				},
+				WorkflowTypes: map[string]*WorkflowTypeSummary{},
+			},
+		},
+		{
+			name:      "mixed",
+			workflows: mixed,
+			want: &Summary{
+				WorkflowTypeSummary: WorkflowTypeSummary{
+					Executions: 6,
+					Statuses: map[enumspb.WorkflowExecutionStatus]int{
+						enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED: 3,
+						enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:    1,
+						enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT: 1,
+						enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:   1,
+					},
+					// The running execution has no duration
+					Durations:            Percentiles[time.Duration]{Count: 5, P50: time.Minute, P95: 10 * time.Minute, P99: 10 * time.Minute, Max: 10 * time.Minute},
+					HistoryLengths:       Percentiles[int]{Count: 6, P50: 10, P95: 12000, P99: 12000, Max: 12000},
+					HistoryLengthBuckets: lengthBuckets(3, 1, 1, 0, 1),
+				},
+				WorkflowTypes: map[string]*WorkflowTypeSummary{
+					"Order": {
+						Executions: 4,
+						Statuses: map[enumspb.WorkflowExecutionStatus]int{
+							enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED: 2,
+							enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:    1,
+							enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:   1,
+						},
+						Durations:            Percentiles[time.Duration]{Count: 3, P50: 2 * time.Minute, P95: 10 * time.Minute, P99: 10 * time.Minute, Max: 10 * time.Minute},
+						HistoryLengths:       Percentiles[int]{Count: 4, P50: 5, P95: 500, P99: 500, Max: 500},
+						HistoryLengthBuckets: lengthBuckets(2, 1, 1, 0, 0),
+					},
+					"Refund": {
+						Executions: 2,
+						Statuses: map[enumspb.WorkflowExecutionStatus]int{
+							enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED: 1,
+							enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT: 1,
+						},
+						Durations:            Percentiles[time.Duration]{Count: 2, P50: 5 * time.Second, P95: 30 * time.Second, P99: 30 * time.Second, Max: 30 * time.Second},
+						HistoryLengths:       Percentiles[int]{Count: 2, P50: 10, P95: 12000, P99: 12000, Max: 12000},
+						HistoryLengthBuckets: lengthBuckets(1, 0, 0, 0, 1),
+					},
+				},
+				ActivityFailures: []*ActivityFailureCount{
+					{ActivityType: "Charge", Failed: 1, TimedOut: 1},
+					{ActivityType: "Ship", Failed: 1},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(tt.workflows)
+			if !reflect.DeepEqual(got.WorkflowTypeSummary, tt.want.WorkflowTypeSummary) {
+				t.Errorf("summary = %+v, want %+v", got.WorkflowTypeSummary, tt.want.WorkflowTypeSummary)
+			}
+			if len(got.WorkflowTypes) != len(tt.want.WorkflowTypes) {
+				t.Errorf("got %d workflow types, want %d", len(got.WorkflowTypes), len(tt.want.WorkflowTypes))
+			}
+			for workflowType, want := range tt.want.WorkflowTypes {
+				if got := got.WorkflowTypes[workflowType]; !reflect.DeepEqual(got, want) {
+					t.Errorf("%s summary = %+v, want %+v", workflowType, got, want)
+				}
+			}
+			if !reflect.DeepEqual(got.ActivityFailures, tt.want.ActivityFailures) {
+				t.Errorf("activity failures = %+v, want %+v", got.ActivityFailures, tt.want.ActivityFailures)
+			}
+
+			// Adding the executions one at a time gives the same summary
+			s := NewSummarizer()
+			for _, workflow := range tt.workflows {
+				s.Add(workflow)
+			}
+			if incremental := s.Summary(); !reflect.DeepEqual(incremental, got) {
+				t.Errorf("incremental summary = %+v, want %+v", incremental, got)
+			}
+		})
+	}
+}
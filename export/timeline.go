@@ -0,0 +1,157 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	"go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+)
+
+// FormatTimeline renders the history of an exported workflow execution as a compact timeline with one line per event,
+// showing the event ID, the time since the workflow started, the event type and the event's key attributes
+func FormatTimeline(workflow *export.WorkflowExecution) string {
+	events := workflow.GetHistory().GetEvents()
+	if len(events) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	start := events[0].GetEventTime().AsTime()
+	// Activities and child workflows are only named on their initiating event, later events refer to it by ID
+	names := map[int64]string{}
+	for _, event := range events {
+		delta := event.GetEventTime().AsTime().Sub(start).Round(time.Millisecond)
+		fmt.Fprintf(tw, "%d\t+%s\t%s\t%s\n", event.GetEventId(), delta, event.GetEventType(), describeEvent(event, names))
+	}
+	tw.Flush()
+
+	// Events without attributes end in an empty cell, which tabwriter still pads after the event type
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// describeEvent returns the key attributes of an event as space separated key=value pairs
+func describeEvent(event *historypb.HistoryEvent, names map[int64]string) string {
+	var attrs []string
+	add := func(key string, value any) {
+		if s := fmt.Sprint(value); s != "" {
+			attrs = append(attrs, fmt.Sprintf("%s=%s", key, s))
+		}
+	}
+	addFailure := func(f *failure.Failure) {
+		if f != nil {
+			attrs = append(attrs, fmt.Sprintf("failure=%q", failureMessage(f)))
+		}
+	}
+
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+		a := event.GetWorkflowExecutionStartedEventAttributes()
+		add("workflowType", a.GetWorkflowType().GetName())
+		add("taskQueue", a.GetTaskQueue().GetName())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		addFailure(event.GetWorkflowExecutionFailedEventAttributes().GetFailure())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		if reason := event.GetWorkflowExecutionTerminatedEventAttributes().GetReason(); reason != "" {
+			add("reason", fmt.Sprintf("%q", reason))
+		}
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		add("newRunId", event.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId())
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED:
+		a := event.GetWorkflowTaskFailedEventAttributes()
+		add("cause", a.GetCause())
+		addFailure(a.GetFailure())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		a := event.GetActivityTaskScheduledEventAttributes()
+		names[event.GetEventId()] = a.GetActivityType().GetName()
+		add("activityType", a.GetActivityType().GetName())
+		add("activityId", a.GetActivityId())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+		a := event.GetActivityTaskStartedEventAttributes()
+		add("activityType", names[a.GetScheduledEventId()])
+		add("attempt", a.GetAttempt())
+		addFailure(a.GetLastFailure())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		add("activityType", names[event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId()])
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		a := event.GetActivityTaskFailedEventAttributes()
+		add("activityType", names[a.GetScheduledEventId()])
+		addFailure(a.GetFailure())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		a := event.GetActivityTaskTimedOutEventAttributes()
+		add("activityType", names[a.GetScheduledEventId()])
+		addFailure(a.GetFailure())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		add("activityType", names[event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId()])
+	case enumspb.EVENT_TYPE_TIMER_STARTED:
+		a := event.GetTimerStartedEventAttributes()
+		add("timerId", a.GetTimerId())
+		add("startToFireTimeout", a.GetStartToFireTimeout().AsDuration())
+	case enumspb.EVENT_TYPE_TIMER_FIRED:
+		add("timerId", event.GetTimerFiredEventAttributes().GetTimerId())
+	case enumspb.EVENT_TYPE_TIMER_CANCELED:
+		add("timerId", event.GetTimerCanceledEventAttributes().GetTimerId())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		add("signalName", event.GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+	case enumspb.EVENT_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+		a := event.GetSignalExternalWorkflowExecutionInitiatedEventAttributes()
+		add("signalName", a.GetSignalName())
+		add("workflowId", a.GetWorkflowExecution().GetWorkflowId())
+	case enumspb.EVENT_TYPE_MARKER_RECORDED:
+		a := event.GetMarkerRecordedEventAttributes()
+		add("markerName", a.GetMarkerName())
+		addFailure(a.GetFailure())
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+		a := event.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+		names[event.GetEventId()] = a.GetWorkflowType().GetName()
+		add("childWorkflowType", a.GetWorkflowType().GetName())
+		add("workflowId", a.GetWorkflowId())
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+		a := event.GetStartChildWorkflowExecutionFailedEventAttributes()
+		add("childWorkflowType", a.GetWorkflowType().GetName())
+		add("cause", a.GetCause())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+		a := event.GetChildWorkflowExecutionStartedEventAttributes()
+		add("childWorkflowType", a.GetWorkflowType().GetName())
+		add("workflowId", a.GetWorkflowExecution().GetWorkflowId())
+		add("runId", a.GetWorkflowExecution().GetRunId())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+		add("childWorkflowType", event.GetChildWorkflowExecutionCompletedEventAttributes().GetWorkflowType().GetName())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+		a := event.GetChildWorkflowExecutionFailedEventAttributes()
+		add("childWorkflowType", a.GetWorkflowType().GetName())
+		addFailure(a.GetFailure())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+		add("childWorkflowType", event.GetChildWorkflowExecutionCanceledEventAttributes().GetWorkflowType().GetName())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:
+		add("childWorkflowType", event.GetChildWorkflowExecutionTimedOutEventAttributes().GetWorkflowType().GetName())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:
+		add("childWorkflowType", event.GetChildWorkflowExecutionTerminatedEventAttributes().GetWorkflowType().GetName())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+		add("updateName", event.GetWorkflowExecutionUpdateAcceptedEventAttributes().GetAcceptedRequest().GetInput().GetName())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_COMPLETED:
+		addFailure(event.GetWorkflowExecutionUpdateCompletedEventAttributes().GetOutcome().GetFailure())
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_SCHEDULED:
+		a := event.GetNexusOperationScheduledEventAttributes()
+		add("service", a.GetService())
+		add("operation", a.GetOperation())
+	case enumspb.EVENT_TYPE_NEXUS_OPERATION_FAILED:
+		addFailure(event.GetNexusOperationFailedEventAttributes().GetFailure())
+	}
+	return strings.Join(attrs, " ")
+}
+
+// failureMessage returns the first line of a failure's message
+func failureMessage(f *failure.Failure) string {
+	message, _, _ := strings.Cut(f.GetMessage(), "\n")
+	return message
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+func TestFormatTimelineTerminationReason(t *testing.T) {
+	workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED)
+	events := workflow.GetHistory().GetEvents()
+	terminated := events[len(events)-1].GetWorkflowExecutionTerminatedEventAttributes()
+
+	lastLine := func() string {
+		lines := strings.Split(strings.TrimSuffix(FormatTimeline(workflow), "\n"), "\n")
+		return lines[len(lines)-1]
+	}
+
+	terminated.Reason = "stuck"
+	if line := lastLine(); !strings.HasSuffix(line, `WorkflowExecutionTerminated  reason="stuck"`) {
+		t.Errorf("timeline line = %q, want the quoted reason", line)
+	}
+	terminated.Reason = ""
+	if line := lastLine(); !strings.HasSuffix(line, "WorkflowExecutionTerminated") {
+		t.Errorf("timeline line = %q, want no reason", line)
+	}
+}
+
+func TestFormatTimelineTrailingWhitespace(t *testing.T) {
+	for _, workflow := range generateWorkflows(t, 20) {
+		timeline := FormatTimeline(workflow)
+		if !strings.HasSuffix(timeline, "\n") {
+			t.Errorf("timeline does not end in a newline")
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(timeline, "\n"), "\n") {
+			if strings.TrimRight(line, " \t") != line {
+				t.Errorf("timeline line %q ends in whitespace", line)
+			}
+		}
+	}
+}
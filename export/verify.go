@@ -0,0 +1,155 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+)
+
+type (
+	// Problem is a structural problem found in an export file
+	Problem struct {
+		// Source identifies the export file, usually its path
+		Source string
+		// Index is the position of the workflow execution in the file starting at 1, or 0 if the problem concerns the
+		// file as a whole
+		Index      int
+		WorkflowID string
+		RunID      string
+		// EventID is the ID of the offending event, or 0 if the problem is not specific to an event
+		EventID int64
+		Message string
+	}
+
+	// Verifier checks exported workflow executions for structural problems. Besides the checks VerifyWorkflow makes on
+	// each execution, it reports run IDs that appear more than once across everything it verified
+	Verifier struct {
+		executions int
+		// runs maps run IDs to where they were first seen
+		runs map[string]location
+	}
+
+	location struct {
+		source string
+		index  int
+	}
+)
+
+func (p *Problem) Error() string {
+	location := p.Source
+	if p.Index > 0 {
+		location += fmt.Sprintf(": workflow %d", p.Index)
+		if p.WorkflowID != "" || p.RunID != "" {
+			location += fmt.Sprintf(" (WorkflowID: %s, RunID: %s)", p.WorkflowID, p.RunID)
+		}
+	}
+	if p.EventID != 0 {
+		location += fmt.Sprintf(": event %d", p.EventID)
+	}
+	return location + ": " + p.Message
+}
+
+// NewVerifier returns an empty Verifier
+func NewVerifier() *Verifier {
+	return &Verifier{runs: map[string]location{}}
+}
+
+// Executions returns the number of workflow executions verified so far
+func (v *Verifier) Executions() int {
+	return v.executions
+}
+
+// VerifyReader verifies every workflow execution in the export read from r and returns all problems found. Reading
+// stops at the first decoding error, such as a truncated file, which is reported as a problem for the file
+func (v *Verifier) VerifyReader(source string, r io.Reader) []*Problem {
+	var problems []*Problem
+	reader := NewReader(r)
+	for {
+		workflow, err := reader.Next()
+		if err == io.EOF {
+			return problems
+		}
+		if err != nil {
+			return append(problems, &Problem{Source: source, Message: err.Error()})
+		}
+		problems = append(problems, v.Verify(source, reader.Count(), workflow)...)
+	}
+}
+
+// Verify verifies the workflow execution found at index in source and returns all problems found
+func (v *Verifier) Verify(source string, index int, workflow *export.WorkflowExecution) []*Problem {
+	v.executions++
+	problems := VerifyWorkflow(workflow)
+	for _, problem := range problems {
+		problem.Source, problem.Index = source, index
+	}
+
+	// Key runs by their reset-aware run ID, since a reset run shares its original run ID with its base run
+	info, err := GetExportedWorkflowExecutionInfo(workflow)
+	if err != nil || info.RunID == "" {
+		return problems
+	}
+	runID := info.RunID
+	if first, ok := v.runs[runID]; ok {
+		return append(problems, &Problem{
+			Source:     source,
+			Index:      index,
+			WorkflowID: info.WorkflowID,
+			RunID:      runID,
+			Message:    fmt.Sprintf("duplicate run ID, first seen in %s workflow %d", first.source, first.index),
+		})
+	}
+	v.runs[runID] = location{source: source, index: index}
+	return problems
+}
+
+// VerifyWorkflow checks the history of a single workflow execution and returns all problems found. The history must
+// start with a WorkflowExecutionStarted event, event IDs must increase by one starting at 1, event times must not go
+// backwards, and the history must end with a close event. Source and Index are left empty in the returned problems
+func VerifyWorkflow(workflow *export.WorkflowExecution) []*Problem {
+	var problems []*Problem
+	problem := func(eventID int64, format string, args ...any) {
+		problems = append(problems, &Problem{EventID: eventID, Message: fmt.Sprintf(format, args...)})
+	}
+
+	events := workflow.GetHistory().GetEvents()
+	if len(events) == 0 {
+		problem(0, "workflow history has no events")
+		return problems
+	}
+
+	if events[0].GetEventType() != enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED {
+		problem(events[0].GetEventId(), "first event is %s, expected WorkflowExecutionStarted", events[0].GetEventType())
+	}
+
+	for i, event := range events {
+		// Compare with the previous event rather than the position so that a single gap is only reported once
+		if i == 0 && event.GetEventId() != 1 {
+			problem(event.GetEventId(), "first event ID is %d, expected 1", event.GetEventId())
+		}
+		if i > 0 && event.GetEventId() != events[i-1].GetEventId()+1 {
+			problem(event.GetEventId(), "event ID %d does not follow previous event ID %d", event.GetEventId(), events[i-1].GetEventId())
+		}
+		if i > 0 && event.GetEventTime().AsTime().Before(events[i-1].GetEventTime().AsTime()) {
+			problem(event.GetEventId(), "event time %s is before the time of the previous event %s",
+				event.GetEventTime().AsTime().Format(time.RFC3339Nano), events[i-1].GetEventTime().AsTime().Format(time.RFC3339Nano))
+		}
+		closed := closeEventStatus(event.GetEventType()) != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING
+		if closed && i < len(events)-1 {
+			problem(event.GetEventId(), "close event %s is followed by %d more events", event.GetEventType(), len(events)-1-i)
+		}
+		if !closed && i == len(events)-1 {
+			problem(0, "history has no close event, last event is %s", event.GetEventType())
+		}
+	}
+
+	if info, err := GetExportedWorkflowExecutionInfo(workflow); err == nil {
+		for _, p := range problems {
+			p.WorkflowID, p.RunID = info.WorkflowID, info.RunID
+		}
+	}
+	return problems
+}
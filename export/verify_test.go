@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/export/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestVerifierResetRuns(t *testing.T) {
+	base := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	reset := resetWorkflow(t, base, "reset-run")
+	baseRunID := base.GetHistory().GetEvents()[0].GetWorkflowExecutionStartedEventAttributes().GetOriginalExecutionRunId()
+
+	verifier := NewVerifier()
+	for i, workflow := range []*export.WorkflowExecution{base, reset} {
+		if problems := verifier.Verify("export.bin", i+1, workflow); len(problems) > 0 {
+			t.Errorf("workflow %d has problems: %v", i+1, problems)
+		}
+	}
+
+	problems := verifier.Verify("other.bin", 1, proto.Clone(reset).(*export.WorkflowExecution))
+	if len(problems) != 1 || problems[0].RunID != "reset-run" || !strings.Contains(problems[0].Message, "first seen in export.bin workflow 2") {
+		t.Errorf("problems = %v, want the reset run reported as a duplicate of workflow 2", problems)
+	}
+	problems = verifier.Verify("other.bin", 2, proto.Clone(base).(*export.WorkflowExecution))
+	if len(problems) != 1 || problems[0].RunID != baseRunID || !strings.Contains(problems[0].Message, "first seen in export.bin workflow 1") {
+		t.Errorf("problems = %v, want the base run reported as a duplicate of workflow 1", problems)
+	}
+	if verifier.Executions() != 4 {
+		t.Errorf("Executions() = %d, want 4", verifier.Executions())
+	}
+}
+
+func TestVerifyWorkflowProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(workflow *export.WorkflowExecution)
+		eventID int64
+		message string
+	}{
+		{
+			name: "event ID gap",
+			modify: func(workflow *export.WorkflowExecution) {
+				events := workflow.GetHistory().GetEvents()
+				for _, event := range events[3:] {
+					event.EventId++
+				}
+			},
+			eventID: 5,
+			message: "event ID 5 does not follow previous event ID 3",
+		},
+		{
+			name: "event time goes backwards",
+			modify: func(workflow *export.WorkflowExecution) {
+				event := workflow.GetHistory().GetEvents()[2]
+				event.EventTime = timestamppb.New(event.GetEventTime().AsTime().Add(-time.Hour))
+			},
+			eventID: 3,
+			message: "is before the time of the previous event",
+		},
+		{
+			name: "missing close event",
+			modify: func(workflow *export.WorkflowExecution) {
+				workflow.History.Events = workflow.History.Events[:len(workflow.History.Events)-1]
+			},
+			message: "history has no close event",
+		},
+		{
+			name: "missing start event",
+			modify: func(workflow *export.WorkflowExecution) {
+				workflow.History.Events = workflow.History.Events[1:]
+			},
+			eventID: 2,
+			message: "first event is",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow := generateWorkflow(t, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+			tt.modify(workflow)
+			for _, problem := range VerifyWorkflow(workflow) {
+				if problem.EventID == tt.eventID && strings.Contains(problem.Message, tt.message) {
+					return
+				}
+			}
+			t.Errorf("VerifyWorkflow() = %v, want a problem at event %d containing %q", VerifyWorkflow(workflow), tt.eventID, tt.message)
+		})
+	}
+}
+
+func TestVerifyReaderTruncated(t *testing.T) {
+	data := serializeWorkflows(t, generateWorkflows(t, 3))
+	verifier := NewVerifier()
+	problems := verifier.VerifyReader("export.bin", bytes.NewReader(data[:len(data)-1]))
+	if len(problems) != 1 || problems[0].Index != 0 || !strings.Contains(problems[0].Message, "truncated") {
+		t.Errorf("problems = %v, want the file reported as truncated", problems)
+	}
+	if verifier.Executions() != 2 {
+		t.Errorf("Executions() = %d, want 2", verifier.Executions())
+	}
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/api/export/v1"
+)
+
+// SerializeExportedWorkflows serializes a WorkflowExecutions object into the uncompressed contents of an export file. It
+// is the inverse of DeserializeExportedWorkflows
+func SerializeExportedWorkflows(workflows *export.WorkflowExecutions) ([]byte, error) {
+	data, err := proto.Marshal(workflows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export file: %w", err)
+	}
+	return data, nil
+}
+
+// Writer writes workflow executions to an export file one at a time, producing the same WorkflowExecutions message as
+// the export feature without holding every execution in memory
+type Writer struct {
+	w     *bufio.Writer
+	count int
+}
+
+// NewWriter returns a Writer that writes an uncompressed export to w. Call Flush once all executions are written
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Write appends a workflow execution to the export
+func (w *Writer) Write(workflow *export.WorkflowExecution) error {
+	data, err := proto.Marshal(workflow)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow %d: %w", w.count+1, err)
+	}
+
+	// Each execution is an element of the repeated WorkflowExecutions.items field
+	buf := protowire.AppendTag(nil, workflowExecutionsItemsField, protowire.BytesType)
+	buf = protowire.AppendVarint(buf, uint64(len(data)))
+	if _, err := w.w.Write(buf); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Count returns the number of workflow executions written so far
+func (w *Writer) Count() int {
+	return w.count
+}
+
+// Flush writes any buffered data to the underlying writer
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriterMatchesSerialize(t *testing.T) {
+	workflows := generateWorkflows(t, 10)
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+	for _, workflow := range workflows {
+		if err := writer.Write(workflow); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if writer.Count() != len(workflows) {
+		t.Errorf("Count() = %d, want %d", writer.Count(), len(workflows))
+	}
+	if !bytes.Equal(buf.Bytes(), serializeWorkflows(t, workflows)) {
+		t.Error("Writer output differs from SerializeExportedWorkflows")
+	}
+}
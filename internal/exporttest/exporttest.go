@@ -0,0 +1,46 @@
+// Package exporttest provides generated workflow executions and export files for tests of packages that consume exports
+package exporttest
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	exportpb "go.temporal.io/api/export/v1"
+
+	"github.com/temporalio/cloud-samples-go/export"
+)
+
+// StartTime is the start time of the first generated execution
+var StartTime = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// Workflows returns count executions generated from the default template with a fixed start time and seed, so every
+// test run sees the same executions
+func Workflows(t testing.TB, count int) []*exportpb.WorkflowExecution {
+	t.Helper()
+	template := export.DefaultGenerateTemplate()
+	template.StartTime = StartTime
+	generator, err := export.NewGenerator(template, 1)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+	workflows := make([]*exportpb.WorkflowExecution, count)
+	for i := range workflows {
+		if workflows[i], err = generator.Next(); err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+	}
+	return workflows
+}
+
+// WriteFile writes workflows to path as an uncompressed export file
+func WriteFile(t testing.TB, path string, workflows []*exportpb.WorkflowExecution) {
+	t.Helper()
+	data, err := export.SerializeExportedWorkflows(&exportpb.WorkflowExecutions{Items: workflows})
+	if err != nil {
+		t.Fatalf("SerializeExportedWorkflows: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"go.temporal.io/sdk/temporal"
-	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 	"google.golang.org/protobuf/proto"
 
@@ -64,7 +63,7 @@ type (
 	}
 )
 
-func registerNamespaceWorkflows(w worker.Worker, wf NamespaceWorkflows) {
+func registerNamespaceWorkflows(w WorkflowRegistry, wf NamespaceWorkflows) {
 	for k, v := range map[string]any{
 		GetNamespaceWorkflowType:        wf.GetNamespace,
 		GetNamespacesWorkflowType:       wf.GetNamespaces,
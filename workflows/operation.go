@@ -8,7 +8,6 @@ import (
 	"github.com/temporalio/cloud-samples-go/workflows/activities"
 	"go.temporal.io/cloud-sdk/api/cloudservice/v1"
 	"go.temporal.io/cloud-sdk/api/operation/v1"
-	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -34,7 +33,7 @@ type (
 	}
 )
 
-func registerAsyncOperationWorkflows(w worker.Worker, wf AsyncOperationWorkflows) {
+func registerAsyncOperationWorkflows(w WorkflowRegistry, wf AsyncOperationWorkflows) {
 	for k, v := range map[string]any{
 		GetAsyncOperationWorkflowType: wf.GetAsyncOperation,
 		WaitForAsyncOperationType:     wf.WaitForAsyncOperation,
@@ -3,7 +3,6 @@ package workflows
 import (
 	"go.temporal.io/cloud-sdk/api/cloudservice/v1"
 	"github.com/temporalio/cloud-samples-go/workflows/activities"
-	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -21,7 +20,7 @@ type (
 	}
 )
 
-func registerRegionWorkflows(w worker.Worker, wf RegionWorkflows) {
+func registerRegionWorkflows(w WorkflowRegistry, wf RegionWorkflows) {
 	for k, v := range map[string]any{
 		GetRegionWorkflowType:     wf.GetRegion,
 		GetAllRegionsWorkflowType: wf.GetAllRegions,
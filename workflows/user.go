@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"go.temporal.io/sdk/temporal"
-	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 	"google.golang.org/protobuf/proto"
 
@@ -81,7 +80,7 @@ func (o *ReconcileUserOutput) setError(err error) {
 	}
 }
 
-func registerUserWorkflows(w worker.Worker, wf UserWorkflows) {
+func registerUserWorkflows(w WorkflowRegistry, wf UserWorkflows) {
 	for k, v := range map[string]any{
 		GetUserWorkflowType:                      wf.GetUser,
 		GetUsersWorkflowType:                     wf.GetUsers,
@@ -4,6 +4,7 @@ import (
 	"github.com/temporalio/cloud-samples-go/client/api"
 	"github.com/temporalio/cloud-samples-go/workflows/activities"
 	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
 )
 
 //go:generate mockgen -source workflows.go -destination workflows_mock.go -package workflow
@@ -20,6 +21,11 @@ type (
 		AsyncOperationWorkflows
 	}
 	workflows struct{}
+
+	// WorkflowRegistry is implemented by both worker.Worker and worker.WorkflowReplayer
+	WorkflowRegistry interface {
+		RegisterWorkflowWithOptions(w interface{}, options workflow.RegisterOptions)
+	}
 )
 
 func NewWorkflows() Workflows {
@@ -32,11 +38,16 @@ func NewActivities(client *api.Client) *activities.Activities {
 
 func Register(w worker.Worker, wf Workflows, a *activities.Activities) {
 	// Register the workflows that we want to be able to use.
+	RegisterWorkflows(w, wf)
+
+	// Register the activities that the workflows will use.
+	activities.Register(w, a)
+}
+
+// RegisterWorkflows registers only the workflows, which is all a worker.WorkflowReplayer needs to replay histories.
+func RegisterWorkflows(w WorkflowRegistry, wf Workflows) {
 	registerUserWorkflows(w, wf)
 	registerNamespaceWorkflows(w, wf)
 	registerRegionWorkflows(w, wf)
 	registerAsyncOperationWorkflows(w, wf)
-
-	// Register the activities that the workflows will use.
-	activities.Register(w, a)
 }